@@ -0,0 +1,120 @@
+package errs
+
+import (
+	"errors"
+	"strings"
+)
+
+// BoxKey defines a capability to see through the errors boxed with it. Only
+// the holders of a key can get the errors boxed with it, using Unbox.
+type BoxKey struct {
+	_ byte
+}
+
+// NewBoxKey creates a new BoxKey, distinct from every other key.
+func NewBoxKey() *BoxKey {
+	return &BoxKey{}
+}
+
+// BoxWithKey creates a new error with the given message boxing the given error
+// with the given key. Like with Box, the error cannot be unwrapped to get the
+// underlying errors, but the boxed error can be got using Unbox with the same
+// key.
+//
+// The chain and the tree of the error hold copies describing the boxed error
+// and the errors resulting to it, with their messages, fields, codes and
+// locations, rather than the errors themselves, so that the boxed errors
+// cannot be got from them either.
+//
+// If the given error is nil, then nil error is returned.
+func BoxWithKey(err error, key *BoxKey, message string, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+	wrp := newWrapping(newFundamental(1, message, fields), seal(err), false)
+	wrp.key = key
+	wrp.boxed = err
+	return wrp
+}
+
+// Unbox gives the error boxed with the given key by the given error, or by any
+// of the errors it wraps. Like errors.As, the errors are searched in a
+// depth-first traversal, following both Unwrap() error and Unwrap() []error,
+// so the errors aggregated by Append or errors.Join are searched as well.
+// Errors boxed without the key are not seen through.
+//
+// If no error is boxed with the key, then nil error is returned.
+func Unbox(err error, key *BoxKey) error {
+	if key == nil {
+		return nil
+	}
+	return unbox(err, key)
+}
+
+func unbox(err error, key *BoxKey) error {
+	for err != nil {
+		if w, ok := err.(*wrapping); ok && !w.wrapped && w.key == key {
+			return w.boxed
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, c := range e.Unwrap() {
+				if boxed := unbox(c, key); boxed != nil {
+					return boxed
+				}
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// seal gives a copy describing the given error, and the errors resulting to
+// it, which cannot be used to get any of them. The copy gives the same Error
+// string, fields, codes and locations as the error.
+func seal(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *fundamental:
+		fdm := *e
+		fdm.origin = nil
+		return &fdm
+	case *sentinelError:
+		fdm := *e.fundamental
+		fdm.origin = nil
+		return &fdm
+	case *wrapping:
+		fdm := *e.fundamental
+		fdm.origin = nil
+		return newWrapping(&fdm, seal(e.err), e.wrapped)
+	case *multi:
+		errs := make([]error, 0, len(e.errs))
+		for _, c := range e.errs {
+			errs = append(errs, seal(c))
+		}
+		return &multi{errs: errs}
+	}
+
+	// Errors not created by this package are described by their Error string,
+	// with their fields kept out of it as they are likely rendered already.
+	fdm := fundamental{msg: err.Error(), renderer: NoFieldsRenderer{}}
+	if ferr, ok := err.(FieldsError); ok {
+		fdm.fields = ferr.Fields()
+	}
+	if cerr, ok := err.(CodeError); ok {
+		fdm.code = cerr.Code()
+	}
+	if lerr, ok := err.(LocationError); ok {
+		fdm.loc.Function, fdm.loc.File, fdm.loc.Line = lerr.Location()
+	}
+	if u := errors.Unwrap(err); u != nil && strings.HasSuffix(fdm.msg, ": "+u.Error()) {
+		fdm.msg = strings.TrimSuffix(fdm.msg, ": "+u.Error())
+		return newWrapping(&fdm, seal(u), true)
+	}
+	return &fdm
+}
@@ -0,0 +1,136 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestBoxWithKey(t *testing.T) {
+	t.Parallel()
+
+	key := errs.NewBoxKey()
+	baseErr := errs.New("base error")
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		if err := errs.BoxWithKey(nil, key, "error occurred"); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("boxed", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.BoxWithKey(baseErr, key, "error occurred")
+
+		if err.Error() != "error occurred: base error" {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), "error occurred: base error")
+		}
+		if errors.Is(err, baseErr) {
+			t.Fatalf("should not wrap base error")
+		}
+		if cause := errs.Unbox(err, key); cause != baseErr {
+			t.Fatalf("Unbox(): got = '%v', want = '%v'", cause, baseErr)
+		}
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.BoxWithKey(baseErr, key, "error occurred")
+
+		if cause := errs.Unbox(err, errs.NewBoxKey()); cause != nil {
+			t.Fatalf("Unbox(): got = '%v', want = nil", cause)
+		}
+		if cause := errs.Unbox(err, nil); cause != nil {
+			t.Fatalf("Unbox(nil): got = '%v', want = nil", cause)
+		}
+		if cause := errs.Unbox(errs.Box(baseErr, "error occurred"), key); cause != nil {
+			t.Fatalf("Unbox(Box()): got = '%v', want = nil", cause)
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.WithCode(errs.BoxWithKey(baseErr, key, "error occurred"), errs.Internal), "outer error")
+
+		if cause := errs.Unbox(err, key); cause != baseErr {
+			t.Fatalf("Unbox(): got = '%v', want = '%v'", cause, baseErr)
+		}
+	})
+
+	t.Run("aggregated", func(t *testing.T) {
+		t.Parallel()
+
+		otherErr := errs.BoxWithKey(errs.New("other error"), errs.NewBoxKey(), "other error occurred")
+		err := errs.Wrap(errs.Append(otherErr, errs.BoxWithKey(baseErr, key, "error occurred")), "outer error")
+
+		if cause := errs.Unbox(err, key); cause != baseErr {
+			t.Fatalf("Unbox(): got = '%v', want = '%v'", cause, baseErr)
+		}
+	})
+
+	t.Run("joined", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.Join(errors.New("other error"), errs.Wrap(errs.BoxWithKey(baseErr, key, "error occurred"), "outer error"))
+
+		if cause := errs.Unbox(err, key); cause != baseErr {
+			t.Fatalf("Unbox(): got = '%v', want = '%v'", cause, baseErr)
+		}
+	})
+
+	t.Run("boxed by others", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Box(errs.BoxWithKey(baseErr, key, "error occurred"), "outer error")
+
+		if cause := errs.Unbox(err, key); cause != nil {
+			t.Fatalf("Unbox(): got = '%v', want = nil", cause)
+		}
+	})
+	t.Run("sealed", func(t *testing.T) {
+		t.Parallel()
+
+		pathErr := &fs.PathError{Op: "open", Path: "config", Err: os.ErrNotExist}
+		codeErr := errs.WithCode(errs.Wrap(baseErr, "inner error"), errs.NotFound)
+		boxed := errs.Append(codeErr, fmt.Errorf("load failed: %w", pathErr))
+		err := errs.BoxWithKey(boxed, key, "error occurred")
+
+		errs.Walk(err, func(n *errs.Node) bool {
+			if n.Err == baseErr || n.Err == codeErr || n.Err == boxed || n.Err == error(pathErr) {
+				t.Fatalf("Walk(): got boxed error = '%v', want = copy", n.Err)
+			}
+			if errors.Is(n.Err, baseErr) || errors.Is(n.Err, os.ErrNotExist) {
+				t.Fatalf("errors.Is(%v): got = %t, want = %t", n.Err, true, false)
+			}
+			var perr *fs.PathError
+			if errors.As(n.Err, &perr) {
+				t.Fatalf("errors.As(%v): got = %t, want = %t", n.Err, true, false)
+			}
+			return true
+		})
+		for _, c := range err.(errs.ChainError).Chain() {
+			if c == boxed {
+				t.Fatalf("Chain(): got boxed error = '%v', want = copy", c)
+			}
+		}
+
+		if err.Error() != "error occurred: "+boxed.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), "error occurred: "+boxed.Error())
+		}
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+		if cause := errs.Unbox(err, key); cause != boxed {
+			t.Fatalf("Unbox(): got = '%v', want = '%v'", cause, boxed)
+		}
+	})
+}
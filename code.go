@@ -0,0 +1,76 @@
+package errs
+
+// Code defines the kind of an error. Unlike fields, the code of an error can
+// be used for logical deductions, like deciding how the error must be handled.
+//
+// Codes other than the well known ones can be defined as needed.
+type Code string
+
+// Well known codes, modeled after the canonical codes of gRPC.
+const (
+	// Canceled indicates the operation was canceled, typically by the caller.
+	Canceled Code = "canceled"
+	// Unknown indicates an error whose kind is not known.
+	Unknown Code = "unknown"
+	// InvalidArgument indicates the caller specified an invalid argument.
+	InvalidArgument Code = "invalid_argument"
+	// DeadlineExceeded indicates the deadline expired before the operation
+	// could complete.
+	DeadlineExceeded Code = "deadline_exceeded"
+	// NotFound indicates some requested entity was not found.
+	NotFound Code = "not_found"
+	// AlreadyExists indicates an entity the caller attempted to create already
+	// exists.
+	AlreadyExists Code = "already_exists"
+	// PermissionDenied indicates the caller does not have permission to
+	// execute the operation.
+	PermissionDenied Code = "permission_denied"
+	// ResourceExhausted indicates some resource has been exhausted.
+	ResourceExhausted Code = "resource_exhausted"
+	// FailedPrecondition indicates the system is not in a state required for
+	// the operation.
+	FailedPrecondition Code = "failed_precondition"
+	// Aborted indicates the operation was aborted, typically due to a
+	// concurrency issue.
+	Aborted Code = "aborted"
+	// OutOfRange indicates the operation was attempted past the valid range.
+	OutOfRange Code = "out_of_range"
+	// Unimplemented indicates the operation is not implemented or supported.
+	Unimplemented Code = "unimplemented"
+	// Internal indicates an internal invariant was broken.
+	Internal Code = "internal"
+	// Unavailable indicates the service is currently unavailable.
+	Unavailable Code = "unavailable"
+	// DataLoss indicates unrecoverable data loss or corruption.
+	DataLoss Code = "data_loss"
+	// Unauthenticated indicates the caller does not have valid authentication
+	// credentials for the operation.
+	Unauthenticated Code = "unauthenticated"
+)
+
+// WithCode gives a copy of the given error classified with the given code.
+// Errors not created by this package are wrapped, so that they can still be
+// unwrapped from the resulting error.
+//
+// If the given error is nil, then nil error is returned.
+func WithCode(err error, code Code) error {
+	return annotate(err, 1, func(f *fundamental) {
+		f.code = code
+	})
+}
+
+// CodeOf gives the code classifying the given error. The errors resulting to
+// the error are searched in the order of Walk, and the outermost code found is
+// returned.
+//
+// If the error is not classified, then empty code is returned.
+func CodeOf(err error) Code {
+	var code Code
+	Walk(err, func(n *Node) bool {
+		if cerr, ok := n.Err.(CodeError); ok {
+			code = cerr.Code()
+		}
+		return len(code) == 0
+	})
+	return code
+}
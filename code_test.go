@@ -0,0 +1,195 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestWithCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.WithCode(nil, errs.NotFound)
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("fundamental", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("error occurred", errs.F("field1", "value1"))
+		err := errs.WithCode(baseErr, errs.NotFound)
+
+		if err.Error() != baseErr.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), baseErr.Error())
+		}
+		if len(err.(errs.FieldsError).Fields()) != 1 {
+			t.Fatalf("len(Fields()): got = %d, want = %d", len(err.(errs.FieldsError).Fields()), 1)
+		}
+		if code := err.(errs.CodeError).Code(); code != errs.NotFound {
+			t.Fatalf("Code(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+		if code := baseErr.(errs.CodeError).Code(); code != "" {
+			t.Fatalf("base Code(): got = '%s', want = '%s'", code, "")
+		}
+	})
+
+	t.Run("wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		wrapErr := errs.Wrap(baseErr, "error occurred")
+		err := errs.WithCode(wrapErr, errs.Internal)
+
+		if err.Error() != wrapErr.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), wrapErr.Error())
+		}
+		if !errors.Is(err, baseErr) {
+			t.Fatalf("should wrap base error")
+		}
+		if code := err.(errs.CodeError).Code(); code != errs.Internal {
+			t.Fatalf("Code(): got = '%s', want = '%s'", code, errs.Internal)
+		}
+		chain := err.(errs.ChainError).Chain()
+		if len(chain) != 2 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(chain), 2)
+		}
+		if code := chain[0].(errs.CodeError).Code(); code != errs.Internal {
+			t.Fatalf("chain[0].Code(): got = '%s', want = '%s'", code, errs.Internal)
+		}
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			err  error
+		}{
+			{name: "fundamental", err: errs.New("error occurred")},
+			{name: "wrapping", err: errs.Wrap(errors.New("base error"), "error occurred")},
+			{name: "sentinel", err: errs.NewSentinel("error occurred").With()},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := errs.WithCode(tt.err, errs.NotFound)
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("errors.Is(WithCode()): got = %t, want = %t", false, true)
+				}
+				if !errors.Is(errs.Retryable(err, 0), tt.err) {
+					t.Fatalf("errors.Is(Retryable(WithCode())): got = %t, want = %t", false, true)
+				}
+				if !errors.Is(errs.WithRenderer(tt.err, errs.NoFieldsRenderer{}), tt.err) {
+					t.Fatalf("errors.Is(WithRenderer()): got = %t, want = %t", false, true)
+				}
+				if errors.Is(tt.err, err) {
+					t.Fatalf("errors.Is(original, WithCode()): got = %t, want = %t", true, false)
+				}
+			})
+		}
+	})
+
+	t.Run("foreign error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := errs.WithCode(baseErr, errs.Unavailable)
+
+		if err.Error() != baseErr.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), baseErr.Error())
+		}
+		if !errors.Is(err, baseErr) {
+			t.Fatalf("should wrap base error")
+		}
+		if code := errs.CodeOf(err); code != errs.Unavailable {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.Unavailable)
+		}
+	})
+}
+
+func TestCodeOf(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		if code := errs.CodeOf(nil); code != "" {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, "")
+		}
+	})
+
+	t.Run("not classified", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.New("base error"), "error occurred")
+		if code := errs.CodeOf(err); code != "" {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, "")
+		}
+	})
+
+	t.Run("through Wrap", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.WithCode(errs.New("base error"), errs.NotFound), "error occurred")
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+	})
+
+	t.Run("through Box", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Box(errs.WithCode(errs.New("base error"), errs.NotFound), "error occurred")
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+	})
+
+	t.Run("through foreign error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.WithCode(errs.New("base error"), errs.PermissionDenied)
+		err := errs.Wrap(fmt.Errorf("foreign error: %w", baseErr), "error occurred")
+		if code := errs.CodeOf(err); code != errs.PermissionDenied {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.PermissionDenied)
+		}
+	})
+
+	t.Run("outermost", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.WithCode(errs.New("base error"), errs.NotFound)
+		err := errs.WithCode(errs.Wrap(baseErr, "error occurred"), errs.Internal)
+		if code := errs.CodeOf(err); code != errs.Internal {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.Internal)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.WithCode(errs.New("base error"), errs.NotFound)
+		data, err := json.Marshal(errs.Wrap(baseErr, "error occurred"))
+		if err != nil {
+			t.Fatalf("json.Marshal(): unexpected error: %v", err)
+		}
+
+		err, uerr := errs.UnmarshalJSON(data)
+		if uerr != nil {
+			t.Fatalf("UnmarshalJSON(): unexpected error: %v", uerr)
+		}
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+	})
+}
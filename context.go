@@ -0,0 +1,83 @@
+package errs
+
+import "context"
+
+type fieldsKey struct{}
+
+// ContextWithFields gives a copy of the given context carrying the given
+// fields, in addition to the ones already carried by the context. When a
+// field with the same key is already carried, it is replaced by the given one.
+//
+// Fields carried by a context are attached to the errors created with the
+// context using NewCtx, WrapCtx and BoxCtx.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, fieldsKey{}, mergeFields(fields, FieldsFromContext(ctx)))
+}
+
+// FieldsFromContext gives the fields carried by the given context.
+func FieldsFromContext(ctx context.Context) []Field {
+	fields, _ := ctx.Value(fieldsKey{}).([]Field)
+	return fields
+}
+
+// NewCtx creates a new error with the given message, like New, with the fields
+// carried by the given context attached after the given fields. Fields carried
+// by the context with the same key as one of the given fields are dropped.
+//
+// If empty message is given, then nil error is returned.
+func NewCtx(ctx context.Context, message string, fields ...Field) error {
+	if len(message) == 0 {
+		return nil
+	}
+	return newFundamental(1, message, mergeFields(fields, FieldsFromContext(ctx)))
+}
+
+// WrapCtx creates a new error with the given message wrapping the given error,
+// like Wrap, with the fields carried by the given context attached as NewCtx
+// does.
+//
+// If the given error is nil, then nil error is returned.
+func WrapCtx(ctx context.Context, err error, message string, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+	return newWrapping(newFundamental(1, message, mergeFields(fields, FieldsFromContext(ctx))), err, true)
+}
+
+// BoxCtx creates a new error with the given message boxing the given error,
+// like Box, with the fields carried by the given context attached as NewCtx
+// does.
+//
+// If the given error is nil, then nil error is returned.
+func BoxCtx(ctx context.Context, err error, message string, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+	return newWrapping(newFundamental(1, message, mergeFields(fields, FieldsFromContext(ctx))), err, false)
+}
+
+// mergeFields gives the given fields followed by the other fields whose keys
+// are not among the given fields.
+func mergeFields(fields []Field, others []Field) []Field {
+	if len(others) == 0 {
+		return fields
+	}
+	merged := make([]Field, 0, len(fields)+len(others))
+	merged = append(merged, fields...)
+	for _, other := range others {
+		dup := false
+		for _, field := range fields {
+			if field.Key() == other.Key() {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			merged = append(merged, other)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,96 @@
+package errs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestContextWithFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := errs.ContextWithFields(context.Background(), errs.String("request_id", "r1"), errs.String("tenant_id", "t1"))
+	ctx = errs.ContextWithFields(ctx, errs.String("request_id", "r2"))
+
+	fields := errs.FieldsFromContext(ctx)
+	want := [][2]interface{}{{"request_id", "r2"}, {"tenant_id", "t1"}}
+	if len(fields) != len(want) {
+		t.Fatalf("len(fields): got = %d, want = %d", len(fields), len(want))
+	}
+	for idx, field := range fields {
+		if field.Key() != want[idx][0] || field.Value() != want[idx][1] {
+			t.Fatalf("fields[%d]: got = '%s=%v', want = '%s=%v'", idx, field.Key(), field.Value(), want[idx][0], want[idx][1])
+		}
+	}
+
+	if fields := errs.FieldsFromContext(context.Background()); fields != nil {
+		t.Fatalf("FieldsFromContext(): got = '%v', want = nil", fields)
+	}
+}
+
+func TestNewCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := errs.ContextWithFields(context.Background(), errs.String("request_id", "r1"), errs.String("tenant_id", "t1"))
+
+	t.Run("empty message", func(t *testing.T) {
+		t.Parallel()
+
+		if err := errs.NewCtx(ctx, ""); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.NewCtx(ctx, "error occurred", errs.String("tenant_id", "t2"))
+		if want := "error occurred (tenant_id=t2 request_id=r1)"; err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+	})
+}
+
+func TestWrapCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := errs.ContextWithFields(context.Background(), errs.String("request_id", "r1"))
+	baseErr := errs.New("base error")
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		if err := errs.WrapCtx(ctx, nil, "error occurred"); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.WrapCtx(ctx, baseErr, "error occurred")
+		if want := "error occurred (request_id=r1): base error"; err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+		if !errors.Is(err, baseErr) {
+			t.Fatalf("errors.Is(): got = %t, want = %t", false, true)
+		}
+	})
+}
+
+func TestBoxCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := errs.ContextWithFields(context.Background(), errs.String("request_id", "r1"))
+	baseErr := errs.New("base error")
+
+	err := errs.BoxCtx(ctx, baseErr, "error occurred")
+	if want := "error occurred (request_id=r1): base error"; err.Error() != want {
+		t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+	}
+	if errors.Is(err, baseErr) {
+		t.Fatalf("errors.Is(): got = %t, want = %t", true, false)
+	}
+}
@@ -2,8 +2,7 @@ package errs
 
 import (
 	"fmt"
-	"runtime"
-	"strings"
+	"time"
 )
 
 // FieldsError defines an error interface with an extra Fields method to get
@@ -32,6 +31,45 @@ type LocationError interface {
 	Location() (fn string, file string, line int)
 }
 
+// StackError defines an error interface with an extra StackTrace method to get
+// the call stack at which error was created.
+//
+// The stack is captured when the error is created but resolved into frames
+// only when StackTrace is called.
+//
+// Stack trace of an error, like Location, must not be used for any logical
+// deductions, it should only be used for reporting purpose.
+type StackError interface {
+	error
+	StackTrace() []Frame
+}
+
+// CodeError defines an error interface with an extra Code method to get the
+// code classifying the error.
+//
+// Unlike fields, the code of an error can be used for logical deductions, like
+// deciding how the error must be handled.
+type CodeError interface {
+	error
+	Code() Code
+}
+
+// RetryableError defines an error interface with extra Retryable and
+// RetryAfter methods to get whether the operation failing with the error can
+// be retried, and the delay after which it should be retried.
+type RetryableError interface {
+	error
+	Retryable() bool
+	RetryAfter() time.Duration
+}
+
+// MultiError defines an error interface with an extra Errors method to get
+// the errors aggregated by the error.
+type MultiError interface {
+	error
+	Errors() []error
+}
+
 // ChainError defines an error interface with an extra Chain method to get
 // chain of errors resulting to the error.
 //
@@ -52,8 +90,7 @@ func New(message string, fields ...Field) error {
 	if len(message) == 0 {
 		return nil
 	}
-	fdm := fundamental{msg: message, fields: fields, loc: getLocation(1)}
-	return &fdm
+	return newFundamental(1, message, fields)
 }
 
 // Wrap creates a new error with the given message wrapping the given error.
@@ -64,17 +101,7 @@ func Wrap(err error, message string, fields ...Field) error {
 	if err == nil {
 		return nil
 	}
-	fdm := fundamental{msg: message, fields: fields, loc: getLocation(1)}
-	var chn []error
-	chn = append(chn, &fdm)
-	switch e := err.(type) {
-	case ChainError:
-		chn = append(chn, e.Chain()...)
-	default:
-		chn = append(chn, e)
-	}
-	wrp := wrapping{fundamental: &fdm, err: err, wrapped: true, chain: chn}
-	return &wrp
+	return newWrapping(newFundamental(1, message, fields), err, true)
 }
 
 // Box creates a new error with the given message boxing the given error.
@@ -85,46 +112,138 @@ func Box(err error, message string, fields ...Field) error {
 	if err == nil {
 		return nil
 	}
-	fdm := fundamental{msg: message, fields: fields, loc: getLocation(1)}
-	var chn []error
-	chn = append(chn, &fdm)
+	return newWrapping(newFundamental(1, message, fields), err, false)
+}
+
+// NewDepth creates a new error with the given message, like New, with the
+// location of the error taken skip frames above the caller of NewDepth. With
+// zero skip it is same as New.
+//
+// It is meant for helpers creating errors on behalf of their callers, which
+// can also mark themselves using Helper instead.
+//
+// If empty message is given, then nil error is returned.
+func NewDepth(skip int, message string, fields ...Field) error {
+	if len(message) == 0 {
+		return nil
+	}
+	return newFundamental(skip+1, message, fields)
+}
+
+// WrapDepth creates a new error with the given message wrapping the given
+// error, like Wrap, with the location of the error taken skip frames above the
+// caller of WrapDepth. With zero skip it is same as Wrap.
+//
+// If the given error is nil, then nil error is returned.
+func WrapDepth(skip int, err error, message string, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+	return newWrapping(newFundamental(skip+1, message, fields), err, true)
+}
+
+// BoxDepth creates a new error with the given message boxing the given error,
+// like Box, with the location of the error taken skip frames above the caller
+// of BoxDepth. With zero skip it is same as Box.
+//
+// If the given error is nil, then nil error is returned.
+func BoxDepth(skip int, err error, message string, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+	return newWrapping(newFundamental(skip+1, message, fields), err, false)
+}
+
+// chainOf gives the chain of errors resulting to the given error.
+func chainOf(err error) []error {
 	switch e := err.(type) {
+	case MultiError:
+		// Aggregated errors branch out, so they are kept whole in the chain
+		// rather than being flattened.
+		return []error{e}
 	case ChainError:
-		chn = append(chn, e.Chain()...)
+		return e.Chain()
 	default:
-		chn = append(chn, e)
+		return []error{e}
 	}
-	wrp := wrapping{fundamental: &fdm, err: err, wrapped: false, chain: chn}
-	return &wrp
+}
+
+// annotate gives a copy of the given error with its outermost fundamental
+// modified by fn. The copy matches the given error using errors.Is. Errors not
+// created by this package are wrapped with an empty message to be modified
+// instead.
+//
+// If the given error is nil, then nil error is returned.
+func annotate(err error, skip int, fn func(f *fundamental)) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *fundamental:
+		fdm := *e
+		fdm.origin = e
+		fn(&fdm)
+		return &fdm
+	case *sentinelError:
+		fdm := *e.fundamental
+		fdm.origin = e
+		fn(&fdm)
+		serr := sentinelError{fundamental: &fdm, sentinel: e.sentinel}
+		return &serr
+	case *wrapping:
+		fdm := *e.fundamental
+		fdm.origin = e
+		fn(&fdm)
+		var chn []error
+		chn = append(chn, &fdm)
+		chn = append(chn, e.chain[1:]...)
+		wrp := *e
+		wrp.fundamental = &fdm
+		wrp.chain = chn
+		return &wrp
+	default:
+		fdm := newFundamental(skip+1, "", nil)
+		fn(fdm)
+		return newWrapping(fdm, err, true)
+	}
+}
+
+// isEmptyLayer reports whether the given error of a chain is a layer with
+// empty message and no fields, like the ones added by annotate to the errors
+// not created by this package. Such layers are left out when the chain is
+// rendered.
+func isEmptyLayer(err error) bool {
+	f, ok := err.(*fundamental)
+	return ok && len(f.msg) == 0 && len(f.fields) == 0
 }
 
 type fundamental struct {
-	msg    string
-	fields []Field
-	loc    location
+	msg      string
+	fields   []Field
+	loc      Location
+	stack    stack
+	code     Code
+	renderer Renderer
+	retry    bool
+	after    time.Duration
+	// origin is the error this is a copy of, when made by annotate.
+	origin error
 }
 
-func (f fundamental) Error() string {
-	if len(f.msg) == 0 {
-		return ""
-	}
-	if len(f.fields) == 0 {
-		return f.msg
+func newFundamental(skip int, msg string, fields []Field) *fundamental {
+	stk := getStack(skip + 1)
+	return &fundamental{
+		msg:    msg,
+		fields: fields,
+		loc:    stk.location(),
+		stack:  stk,
 	}
-	return fmt.Sprintf("%s (%s)", f.msg, f.fieldsString())
 }
 
-func (f fundamental) fieldsString() string {
-	var sb strings.Builder
-	for idx, field := range f.fields {
-		sb.WriteString(field.Key())
-		sb.WriteString("=")
-		sb.WriteString(valueString(field))
-		if idx < len(f.fields)-1 {
-			sb.WriteString(" ")
-		}
+func (f fundamental) Error() string {
+	if len(f.msg) == 0 {
+		return ""
 	}
-	return sb.String()
+	return rendererOf(f.renderer).Render(f.msg, f.fields)
 }
 
 // Fields gives the fields associated with the error.
@@ -135,9 +254,57 @@ func (f fundamental) Fields() []Field {
 }
 
 // Location gives function name, file name and line number of the location
-// where error was created.
+// where error was created. The file is trimmed as set by SetTrimPrefixes.
 func (f fundamental) Location() (fn string, file string, line int) {
-	return f.loc.Function, f.loc.File, f.loc.Line
+	return f.loc.Function, trimFile(f.loc.Function, f.loc.File), f.loc.Line
+}
+
+// Code gives the code classifying the error. If error is not classified then
+// empty code is returned.
+func (f fundamental) Code() Code {
+	return f.code
+}
+
+// Retryable reports whether the operation failing with the error can be
+// retried.
+func (f fundamental) Retryable() bool {
+	return f.retry
+}
+
+// RetryAfter gives the delay after which the operation failing with the error
+// should be retried. Zero delay means the operation can be retried right away.
+func (f fundamental) RetryAfter() time.Duration {
+	return f.after
+}
+
+// Is reports whether the error is a copy of the given error, made by
+// annotating it like WithCode does, so that the copies of an error match it.
+func (f fundamental) Is(target error) bool {
+	for origin := f.origin; origin != nil; origin = originOf(origin) {
+		if origin == target {
+			return true
+		}
+	}
+	return false
+}
+
+func originOf(err error) error {
+	switch e := err.(type) {
+	case *fundamental:
+		return e.origin
+	case *sentinelError:
+		return e.origin
+	case *wrapping:
+		return e.origin
+	default:
+		return nil
+	}
+}
+
+// StackTrace gives the frames of the call stack at which error was created,
+// innermost first.
+func (f fundamental) StackTrace() []Frame {
+	return f.stack.frames()
 }
 
 type wrapping struct {
@@ -145,6 +312,16 @@ type wrapping struct {
 	chain   []error
 	err     error
 	wrapped bool
+	key     *BoxKey
+	// boxed is the error boxed with the key, as err holds a copy of it.
+	boxed error
+}
+
+func newWrapping(fdm *fundamental, err error, wrapped bool) *wrapping {
+	var chn []error
+	chn = append(chn, fdm)
+	chn = append(chn, chainOf(err)...)
+	return &wrapping{fundamental: fdm, err: err, wrapped: wrapped, chain: chn}
 }
 
 // Chain gives the chain of errors associated with the error.
@@ -165,6 +342,9 @@ func (w wrapping) Error() string {
 	if len(fes) == 0 && w.err != nil {
 		return w.err.Error()
 	}
+	if r, ok := rendererOf(w.renderer).(CauseRenderer); ok {
+		return r.RenderCause(w.msg, w.fields, w.err.Error())
+	}
 	return fmt.Sprintf("%s: %s", fes, w.err.Error())
 }
 
@@ -177,21 +357,17 @@ func (w wrapping) Unwrap() error {
 	return w.err
 }
 
-type location struct {
-	Function string
-	File     string
-	Line     int
-}
-
-func getLocation(skip int) location {
-	pc, file, line, ok := runtime.Caller(skip + 1)
-	if !ok {
-		return location{}
-	}
-	fn := runtime.FuncForPC(pc)
-	return location{
-		Function: fn.Name(),
-		File:     file,
-		Line:     line,
+// message gives the message of the given error without the fields and the
+// chain. For errors not created by this package it is the Error string.
+func message(err error) string {
+	switch e := err.(type) {
+	case *fundamental:
+		return e.msg
+	case *wrapping:
+		return e.msg
+	case *sentinelError:
+		return e.msg
+	default:
+		return e.Error()
 	}
 }
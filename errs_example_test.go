@@ -96,6 +96,19 @@ func ExampleWrap_chain() {
 	// [error occurred (temperature=10 state=heating) something failed]
 }
 
+func ExampleNewSentinel() {
+	ErrNotFound := errs.NewSentinel("not found")
+
+	fn := func() error {
+		return ErrNotFound.With(errs.String("id", "10"))
+	}
+	fmt.Println(fn())
+	fmt.Println(errors.Is(fn(), ErrNotFound))
+	// Output:
+	// not found (id=10)
+	// true
+}
+
 const ErrSomethingFailed testErr = "something failed"
 
 func doSomething(_ ...string) error {
@@ -0,0 +1,4 @@
+package errs
+
+// ValueString exports valueString for the tests.
+var ValueString = valueString
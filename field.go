@@ -2,46 +2,273 @@ package errs
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 )
 
-// Field defines a key-value pair.
-type Field interface {
-	Key() string
-	Value() interface{}
-}
-
 // F creates a new Field with the given key and value.
+//
+// The value is boxed into an interface{} and rendered using its %v format,
+// so the typed constructors like String and Int should be preferred on hot
+// paths.
 func F(key string, val interface{}) Field {
-	return field{key: key, val: val}
+	return Field{key: key, typ: anyType, val: val}
+}
+
+// Any creates a new Field with the given key and value. It is same as F.
+func Any(key string, val interface{}) Field {
+	return F(key, val)
+}
+
+// String creates a new Field with the given key and string value.
+func String(key string, val string) Field {
+	return Field{key: key, typ: stringType, str: val}
 }
 
-type field struct {
-	key string
-	val interface{}
+// Int creates a new Field with the given key and int value.
+func Int(key string, val int) Field {
+	return Field{key: key, typ: intType, num: int64(val)}
+}
+
+// Int64 creates a new Field with the given key and int64 value.
+func Int64(key string, val int64) Field {
+	return Field{key: key, typ: int64Type, num: val}
+}
+
+// Float64 creates a new Field with the given key and float64 value.
+func Float64(key string, val float64) Field {
+	return Field{key: key, typ: float64Type, num: int64(math.Float64bits(val))}
+}
+
+// Bool creates a new Field with the given key and bool value.
+func Bool(key string, val bool) Field {
+	var num int64
+	if val {
+		num = 1
+	}
+	return Field{key: key, typ: boolType, num: num}
+}
+
+// Duration creates a new Field with the given key and time.Duration value.
+func Duration(key string, val time.Duration) Field {
+	return Field{key: key, typ: durationType, num: int64(val)}
+}
+
+// Time creates a new Field with the given key and time.Time value.
+func Time(key string, val time.Time) Field {
+	if val.Before(minTime) || val.After(maxTime) {
+		// Times which cannot be represented as nanoseconds since the epoch
+		// are kept as is.
+		return Field{key: key, typ: anyType, val: val}
+	}
+	return Field{key: key, typ: timeType, num: val.UnixNano(), val: val.Location()}
+}
+
+// Stringer creates a new Field with the given key and fmt.Stringer value. The
+// value is rendered using its String method.
+func Stringer(key string, val fmt.Stringer) Field {
+	return Field{key: key, typ: stringerType, val: val}
+}
+
+// Public gives a copy of the given field marked as public, meaning its value
+// is safe to be exposed to the clients of a service, like in the responses of
+// package httperr. Fields are not public by default.
+func Public(f Field) Field {
+	f.public = true
+	return f
+}
+
+// IsPublic reports whether the given field is marked as public using Public.
+func IsPublic(f Field) bool {
+	return f.public
+}
+
+// AllFields gives the fields of the given error and of all the errors
+// resulting to it, following the causal graph of the error in the order of
+// Walk.
+//
+// When multiple errors have fields with the same key, only the field of the
+// outermost error, the one visited first by Walk, is kept.
+func AllFields(err error) []Field {
+	var fields []Field
+	seen := make(map[string]struct{})
+	Walk(err, func(n *Node) bool {
+		ferr, ok := n.Err.(FieldsError)
+		if !ok {
+			return true
+		}
+		for _, field := range ferr.Fields() {
+			if _, ok := seen[field.Key()]; ok {
+				continue
+			}
+			seen[field.Key()] = struct{}{}
+			fields = append(fields, field)
+		}
+		return true
+	})
+	return fields
+}
+
+// Lookup gives the value of the field with the given key, searching the fields
+// of the given error and of all the errors resulting to it. As with AllFields,
+// the field of the outermost error is used when multiple errors have it.
+func Lookup(err error, key string) (interface{}, bool) {
+	field, ok := lookup(err, key)
+	if !ok {
+		return nil, false
+	}
+	return field.Value(), true
+}
+
+// LookupAs gives the value of the field with the given key as a value of type
+// T, searching like Lookup. If the field is found but its value is not of type
+// T, then the zero value and false are returned.
+func LookupAs[T any](err error, key string) (T, bool) {
+	var zero T
+	val, ok := Lookup(err, key)
+	if !ok {
+		return zero, false
+	}
+	tval, ok := val.(T)
+	if !ok {
+		return zero, false
+	}
+	return tval, true
+}
+
+// HasField reports whether the given error or any of the errors resulting to
+// it has a field with the given key.
+func HasField(err error, key string) bool {
+	_, ok := lookup(err, key)
+	return ok
+}
+
+func lookup(err error, key string) (Field, bool) {
+	var found Field
+	var ok bool
+	Walk(err, func(n *Node) bool {
+		ferr, isFields := n.Err.(FieldsError)
+		if !isFields {
+			return true
+		}
+		for _, field := range ferr.Fields() {
+			if field.Key() == key {
+				found, ok = field, true
+				return false
+			}
+		}
+		return true
+	})
+	return found, ok
+}
+
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+type fieldType uint8
+
+const (
+	anyType fieldType = iota
+	stringType
+	intType
+	int64Type
+	float64Type
+	boolType
+	durationType
+	timeType
+	stringerType
+)
+
+// Field defines a key-value pair.
+//
+// Field is a tagged union of the values of the supported types, so that typed
+// values need not be boxed into an interface{}, and fields can be created
+// without allocating.
+type Field struct {
+	key    string
+	str    string
+	val    interface{}
+	num    int64
+	typ    fieldType
+	secret bool
+	public bool
 }
 
 // Key gives the key of field.
-func (f field) Key() string {
+func (f Field) Key() string {
 	return f.key
 }
 
-// Value gives the value of field.
-func (f field) Value() interface{} {
-	return f.val
+// Value gives the value of field. If the field is redacted, then Redacted is
+// returned instead.
+func (f Field) Value() interface{} {
+	if f.redacted() {
+		return Redacted
+	}
+	return f.raw()
+}
+
+func (f Field) raw() interface{} {
+	switch f.typ {
+	case stringType:
+		return f.str
+	case intType:
+		return int(f.num)
+	case int64Type:
+		return f.num
+	case float64Type:
+		return math.Float64frombits(uint64(f.num))
+	case boolType:
+		return f.num == 1
+	case durationType:
+		return time.Duration(f.num)
+	case timeType:
+		return time.Unix(0, f.num).In(f.val.(*time.Location))
+	default:
+		return f.val
+	}
 }
 
+// valueString gives the string representation of the value of the given
+// field, as it is rendered in the Error string by the default renderer.
 func valueString(f Field) string {
-	natural := fmt.Sprintf("%v", f.Value())
+	return string(appendValue(nil, f, time.RFC3339))
+}
+
+// appendValue appends the string representation of the value of the given
+// field to buf, formatting the time values with the given layout.
+func appendValue(buf []byte, fld Field, layout string) []byte {
+	if fld.redacted() {
+		return append(buf, Redacted...)
+	}
+	switch fld.typ {
+	case stringType:
+		return append(buf, fld.str...)
+	case intType, int64Type:
+		return strconv.AppendInt(buf, fld.num, 10)
+	case float64Type:
+		return strconv.AppendFloat(buf, math.Float64frombits(uint64(fld.num)), 'g', -1, 64)
+	case boolType:
+		return strconv.AppendBool(buf, fld.num == 1)
+	case durationType:
+		return append(buf, time.Duration(fld.num).String()...)
+	case timeType:
+		return time.Unix(0, fld.num).In(fld.val.(*time.Location)).AppendFormat(buf, layout)
+	default:
+		return appendAny(buf, fld.val, layout)
+	}
+}
 
-	switch val := f.Value().(type) {
-	case nil:
-		return natural
+func appendAny(buf []byte, val interface{}, layout string) []byte {
+	switch v := val.(type) {
 	case time.Time:
-		return val.Format(time.RFC3339)
+		return v.AppendFormat(buf, layout)
 	case *time.Time:
-		return val.Format(time.RFC3339)
+		return v.AppendFormat(buf, layout)
 	default:
-		return natural
+		return fmt.Appendf(buf, "%v", v)
 	}
 }
@@ -1 +1,211 @@
 package errs_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hemantjadon/errs"
+)
+
+type stringer struct{}
+
+func (stringer) String() string {
+	return "stringer"
+}
+
+func TestTypedFields(t *testing.T) {
+	t.Parallel()
+
+	tt := time.Date(2021, 6, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		field  errs.Field
+		value  interface{}
+		render string
+	}{
+		{name: "String", field: errs.String("key", "value"), value: "value", render: "value"},
+		{name: "Int", field: errs.Int("key", -10), value: -10, render: "-10"},
+		{name: "Int64", field: errs.Int64("key", 10), value: int64(10), render: "10"},
+		{name: "Float64", field: errs.Float64("key", 12.34), value: 12.34, render: "12.34"},
+		{name: "Bool", field: errs.Bool("key", true), value: true, render: "true"},
+		{name: "Duration", field: errs.Duration("key", 2*time.Second), value: 2 * time.Second, render: "2s"},
+		{name: "Time", field: errs.Time("key", tt), value: tt, render: tt.Format(time.RFC3339)},
+		{name: "Stringer", field: errs.Stringer("key", stringer{}), value: stringer{}, render: "stringer"},
+		{name: "Any", field: errs.Any("key", 10), value: 10, render: "10"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.field.Key() != "key" {
+				t.Fatalf("Key(): got = '%s', want = '%s'", tt.field.Key(), "key")
+			}
+			if val, ok := tt.field.Value().(time.Time); ok {
+				if !val.Equal(tt.value.(time.Time)) {
+					t.Fatalf("Value(): got = '%v', want = '%v'", val, tt.value)
+				}
+			} else if tt.field.Value() != tt.value {
+				t.Fatalf("Value(): got = '%v', want = '%v'", tt.field.Value(), tt.value)
+			}
+			if got := errs.ValueString(tt.field); got != tt.render {
+				t.Fatalf("ValueString(): got = '%s', want = '%s'", got, tt.render)
+			}
+			if got := errs.ValueString(errs.F("key", tt.value)); got != tt.render {
+				t.Fatalf("ValueString(F()): got = '%s', want = '%s'", got, tt.render)
+			}
+		})
+	}
+}
+
+func TestTypedFieldsAllocs(t *testing.T) {
+	fields := make([]errs.Field, 4)
+	allocs := testing.AllocsPerRun(100, func() {
+		fields[0] = errs.Int("count", 10)
+		fields[1] = errs.Float64("ratio", 0.5)
+		fields[2] = errs.Duration("duration", time.Second)
+		fields[3] = errs.String("state", "heating")
+	})
+	if allocs != 0 {
+		t.Fatalf("allocs: got = %v, want = %v", allocs, 0)
+	}
+}
+
+var fieldsSink []errs.Field
+
+func BenchmarkFields(b *testing.B) {
+	b.Run("create/F", func(b *testing.B) {
+		b.ReportAllocs()
+		fields := make([]errs.Field, 4)
+		for i := 0; i < b.N; i++ {
+			fields[0] = errs.F("count", i)
+			fields[1] = errs.F("ratio", 0.5+float64(i))
+			fields[2] = errs.F("duration", time.Duration(i))
+			fields[3] = errs.F("state", "heating")
+		}
+		fieldsSink = fields
+	})
+
+	b.Run("create/typed", func(b *testing.B) {
+		b.ReportAllocs()
+		fields := make([]errs.Field, 4)
+		for i := 0; i < b.N; i++ {
+			fields[0] = errs.Int("count", i)
+			fields[1] = errs.Float64("ratio", 0.5+float64(i))
+			fields[2] = errs.Duration("duration", time.Duration(i))
+			fields[3] = errs.String("state", "heating")
+		}
+		fieldsSink = fields
+	})
+
+	b.Run("render/F", func(b *testing.B) {
+		err := errs.New("error occurred", errs.F("count", 10), errs.F("ratio", 0.5), errs.F("ok", true), errs.F("state", "heating"))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = err.Error()
+		}
+	})
+
+	b.Run("render/typed", func(b *testing.B) {
+		err := errs.New("error occurred", errs.Int("count", 10), errs.Float64("ratio", 0.5), errs.Bool("ok", true), errs.String("state", "heating"))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = err.Error()
+		}
+	})
+}
+
+func TestAllFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		if fields := errs.AllFields(nil); len(fields) != 0 {
+			t.Fatalf("len(AllFields()): got = %d, want = %d", len(fields), 0)
+		}
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		t.Parallel()
+
+		err1 := errs.New("error one", errs.String("key1", "inner"), errs.String("key2", "value2"))
+		err2 := errs.New("error two", errs.String("key3", "value3"))
+		err := errs.Box(errs.Append(err1, err2), "error occurred", errs.String("key1", "outer"), errs.String("key4", "value4"))
+
+		fields := errs.AllFields(err)
+		want := []struct{ key, val string }{
+			{key: "key1", val: "outer"},
+			{key: "key4", val: "value4"},
+			{key: "key2", val: "value2"},
+			{key: "key3", val: "value3"},
+		}
+		if len(fields) != len(want) {
+			t.Fatalf("len(AllFields()): got = %d, want = %d", len(fields), len(want))
+		}
+		for idx, w := range want {
+			if fields[idx].Key() != w.key || fields[idx].Value() != w.val {
+				t.Fatalf("fields[%d]: got = '%s=%v', want = '%s=%s'", idx, fields[idx].Key(), fields[idx].Value(), w.key, w.val)
+			}
+		}
+	})
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errs.New("base error", errs.String("request_id", "inner"), errs.Int("user_id", 10))
+	err := errs.Wrap(fmt.Errorf("foreign error: %w", baseErr), "error occurred", errs.String("request_id", "outer"))
+
+	t.Run("Lookup", func(t *testing.T) {
+		t.Parallel()
+
+		val, ok := errs.Lookup(err, "request_id")
+		if !ok || val != "outer" {
+			t.Fatalf("Lookup(request_id): got = '%v, %t', want = '%s, %t'", val, ok, "outer", true)
+		}
+		val, ok = errs.Lookup(err, "user_id")
+		if !ok || val != 10 {
+			t.Fatalf("Lookup(user_id): got = '%v, %t', want = '%d, %t'", val, ok, 10, true)
+		}
+		val, ok = errs.Lookup(err, "missing")
+		if ok || val != nil {
+			t.Fatalf("Lookup(missing): got = '%v, %t', want = '%v, %t'", val, ok, nil, false)
+		}
+		val, ok = errs.Lookup(nil, "request_id")
+		if ok || val != nil {
+			t.Fatalf("Lookup(nil): got = '%v, %t', want = '%v, %t'", val, ok, nil, false)
+		}
+	})
+
+	t.Run("LookupAs", func(t *testing.T) {
+		t.Parallel()
+
+		id, ok := errs.LookupAs[int](err, "user_id")
+		if !ok || id != 10 {
+			t.Fatalf("LookupAs[int](user_id): got = '%d, %t', want = '%d, %t'", id, ok, 10, true)
+		}
+		str, ok := errs.LookupAs[string](err, "user_id")
+		if ok || str != "" {
+			t.Fatalf("LookupAs[string](user_id): got = '%s, %t', want = '%s, %t'", str, ok, "", false)
+		}
+		str, ok = errs.LookupAs[string](err, "missing")
+		if ok || str != "" {
+			t.Fatalf("LookupAs[string](missing): got = '%s, %t', want = '%s, %t'", str, ok, "", false)
+		}
+	})
+
+	t.Run("HasField", func(t *testing.T) {
+		t.Parallel()
+
+		if !errs.HasField(err, "user_id") {
+			t.Fatalf("HasField(user_id): got = %t, want = %t", false, true)
+		}
+		if errs.HasField(err, "missing") {
+			t.Fatalf("HasField(missing): got = %t, want = %t", true, false)
+		}
+	})
+}
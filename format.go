@@ -0,0 +1,70 @@
+package errs
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format formats the error according to the fmt.Formatter interface.
+//
+//	%s    error string
+//	%v    same as %s
+//	%q    double-quoted error string
+//	%+v   each error of the chain on its own line along with its location
+func (f fundamental) Format(s fmt.State, verb rune) {
+	format(s, verb, f, []error{f})
+}
+
+// Format formats the error according to the fmt.Formatter interface.
+//
+//	%s    error string
+//	%v    same as %s
+//	%q    double-quoted error string
+//	%+v   each error of the chain on its own line along with its location
+func (w wrapping) Format(s fmt.State, verb rune) {
+	format(s, verb, w, w.chain)
+}
+
+func format(s fmt.State, verb rune, err error, chain []error) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			formatVerbose(s, chain)
+			return
+		}
+		io.WriteString(s, err.Error())
+	case 's':
+		io.WriteString(s, err.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", err.Error())
+	}
+}
+
+func formatVerbose(w io.Writer, chain []error) {
+	first := true
+	for _, err := range chain {
+		if isEmptyLayer(err) {
+			continue
+		}
+		if !first {
+			io.WriteString(w, "\n")
+		}
+		first = false
+		if _, ok := err.(MultiError); ok {
+			// Aggregated errors are formatted with their own locations, the
+			// same as when formatting them alone.
+			fmt.Fprintf(w, "%+v", err)
+			continue
+		}
+		io.WriteString(w, err.Error())
+		lerr, ok := err.(LocationError)
+		if !ok {
+			continue
+		}
+		fn, file, line := lerr.Location()
+		if len(fn) == 0 && len(file) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n\t%s\n\t\t%s:%d", fn, file, line)
+	}
+}
@@ -0,0 +1,102 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errors.New("base error")
+	innerErr := errs.Wrap(baseErr, "inner error", errs.F("field1", "value1"))
+	err := errs.Box(innerErr, "error occurred", errs.F("field2", "value2"))
+
+	t.Run("%s", func(t *testing.T) {
+		t.Parallel()
+
+		got := fmt.Sprintf("%s", err)
+		if got != err.Error() {
+			t.Fatalf("got = '%s', want = '%s'", got, err.Error())
+		}
+	})
+
+	t.Run("%v", func(t *testing.T) {
+		t.Parallel()
+
+		got := fmt.Sprintf("%v", err)
+		if got != err.Error() {
+			t.Fatalf("got = '%s', want = '%s'", got, err.Error())
+		}
+	})
+
+	t.Run("%q", func(t *testing.T) {
+		t.Parallel()
+
+		got := fmt.Sprintf("%q", err)
+		want := fmt.Sprintf("%q", err.Error())
+		if got != want {
+			t.Fatalf("got = '%s', want = '%s'", got, want)
+		}
+	})
+
+	t.Run("%+v", func(t *testing.T) {
+		t.Parallel()
+
+		got := fmt.Sprintf("%+v", err)
+		lines := strings.Split(got, "\n")
+		if len(lines) != 7 {
+			t.Fatalf("len(lines): got = %d, want = %d\n%s", len(lines), 7, got)
+		}
+		if lines[0] != "error occurred (field2=value2)" {
+			t.Fatalf("lines[0]: got = '%s', want = '%s'", lines[0], "error occurred (field2=value2)")
+		}
+		if !strings.HasSuffix(lines[1], "TestFormat") {
+			t.Fatalf("lines[1]: got = '%s', want suffix = '%s'", lines[1], "TestFormat")
+		}
+		if !strings.Contains(lines[2], "format_test.go:") {
+			t.Fatalf("lines[2]: got = '%s', want contains = '%s'", lines[2], "format_test.go:")
+		}
+		if lines[3] != "inner error (field1=value1)" {
+			t.Fatalf("lines[3]: got = '%s', want = '%s'", lines[3], "inner error (field1=value1)")
+		}
+		if lines[6] != baseErr.Error() {
+			t.Fatalf("lines[6]: got = '%s', want = '%s'", lines[6], baseErr.Error())
+		}
+	})
+
+	t.Run("%+v fundamental", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.New("error occurred")
+
+		got := fmt.Sprintf("%+v", err)
+		lines := strings.Split(got, "\n")
+		if len(lines) != 3 {
+			t.Fatalf("len(lines): got = %d, want = %d\n%s", len(lines), 3, got)
+		}
+		if lines[0] != err.Error() {
+			t.Fatalf("lines[0]: got = '%s', want = '%s'", lines[0], err.Error())
+		}
+	})
+
+	t.Run("%+v empty layer", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := errs.Wrap(errs.WithCode(baseErr, errs.NotFound), "error occurred")
+
+		got := fmt.Sprintf("%+v", err)
+		lines := strings.Split(got, "\n")
+		if len(lines) != 4 {
+			t.Fatalf("len(lines): got = %d, want = %d\n%s", len(lines), 4, got)
+		}
+		if lines[3] != baseErr.Error() {
+			t.Fatalf("lines[3]: got = '%s', want = '%s'", lines[3], baseErr.Error())
+		}
+	})
+}
@@ -1,3 +1,3 @@
 module github.com/hemantjadon/errs
 
-go 1.14
+go 1.21
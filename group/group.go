@@ -0,0 +1,100 @@
+// Package group provides running of tasks concurrently, collecting the errors
+// of all the failing tasks rather than only of the first one.
+package group
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hemantjadon/errs"
+)
+
+// Group runs a collection of tasks concurrently with bounded concurrency.
+//
+// A Group must be created using WithContext.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	next int
+	errs []error
+}
+
+// WithContext creates a new Group running at most limit tasks at a time, and a
+// context derived from the given one. Negative or zero limit means no limit.
+//
+// The derived context is canceled when a task fails, with the error of the
+// task as the cause, or when Wait returns, whichever occurs first.
+func WithContext(ctx context.Context, limit int) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := Group{ctx: ctx, cancel: cancel}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return &g, ctx
+}
+
+// Go runs the given task in a new goroutine, blocking until it can be run
+// within the limit of the Group.
+//
+// The error returned by the task is wrapped with the name and the index of the
+// task, as the fields "task" and "index", and panics in the task are converted
+// into errors as errs.Recover does. Errors of tasks which fail because the
+// context of the Group is canceled by another task carry the cause of the
+// cancellation as the field "cause".
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.mu.Lock()
+	idx := g.next
+	g.next++
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		err := errs.Call(func() error { return fn(g.ctx) })
+		if err == nil {
+			return
+		}
+
+		fields := []errs.Field{errs.String("task", name), errs.Int("index", idx)}
+		if g.ctx.Err() != nil && errors.Is(err, g.ctx.Err()) {
+			if cause := context.Cause(g.ctx); cause != g.ctx.Err() {
+				fields = append(fields, errs.F("cause", cause))
+			}
+		}
+		err = errs.Wrap(err, "task failed", fields...)
+
+		g.mu.Lock()
+		g.errs[idx] = err
+		g.mu.Unlock()
+
+		g.cancel(err)
+	}()
+}
+
+// Wait blocks until all the tasks run by the Group are done, giving the
+// errors of the failed tasks, in the order the tasks were run, aggregated
+// using errs.Append.
+//
+// If no task failed, then nil error is returned.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errs.Append(nil, g.errs...)
+}
@@ -0,0 +1,133 @@
+package group_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hemantjadon/errs"
+	"github.com/hemantjadon/errs/group"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		g, _ := group.WithContext(context.Background(), 0)
+		for _, name := range []string{"first", "second"} {
+			g.Go(name, func(context.Context) error { return nil })
+		}
+		if err := g.Wait(); err != nil {
+			t.Fatalf("Wait(): unexpected error: %v", err)
+		}
+	})
+
+	t.Run("all failures", func(t *testing.T) {
+		t.Parallel()
+
+		err1, err2 := errs.New("first error"), errs.New("second error")
+
+		g, _ := group.WithContext(context.Background(), 0)
+		g.Go("first", func(context.Context) error { return err1 })
+		g.Go("second", func(context.Context) error { return err2 })
+		g.Go("third", func(context.Context) error { return nil })
+
+		err := g.Wait()
+		merr, ok := err.(errs.MultiError)
+		if !ok {
+			t.Fatalf("got type = '%T', want = 'MultiError'", err)
+		}
+		if _, ok := err.(errs.ChainError); !ok {
+			t.Fatalf("got type = '%T', want = 'ChainError'", err)
+		}
+		if !errors.Is(err, err1) || !errors.Is(err, err2) {
+			t.Fatalf("errors.Is(): got = %t, want = %t", false, true)
+		}
+
+		for idx, want := range []string{"first", "second"} {
+			task, ok := errs.LookupAs[string](merr.Errors()[idx], "task")
+			if !ok || task != want {
+				t.Fatalf("LookupAs[string](task): got = '%s, %t', want = '%s, %t'", task, ok, want, true)
+			}
+			index, ok := errs.LookupAs[int](merr.Errors()[idx], "index")
+			if !ok || index != idx {
+				t.Fatalf("LookupAs[int](index): got = '%d, %t', want = '%d, %t'", index, ok, idx, true)
+			}
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		g, _ := group.WithContext(context.Background(), 0)
+		g.Go("panicking", func(context.Context) error { panic("boom") })
+
+		err := g.Wait()
+		if !errs.HasField(err, "value") {
+			t.Fatalf("HasField(value): got = %t, want = %t", false, true)
+		}
+		if task, _ := errs.LookupAs[string](err, "task"); task != "panicking" {
+			t.Fatalf("LookupAs[string](task): got = '%s', want = '%s'", task, "panicking")
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+
+		const limit = 2
+
+		var running, peak atomic.Int32
+		g, _ := group.WithContext(context.Background(), limit)
+		for i := 0; i < 8; i++ {
+			g.Go("task", func(context.Context) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			t.Fatalf("Wait(): unexpected error: %v", err)
+		}
+		if peak.Load() > limit {
+			t.Fatalf("peak: got = %d, want <= %d", peak.Load(), limit)
+		}
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("error occurred")
+
+		g, ctx := group.WithContext(context.Background(), 0)
+		g.Go("failing", func(context.Context) error { return baseErr })
+		g.Go("waiting", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		err := g.Wait()
+		if !errors.Is(context.Cause(ctx), baseErr) {
+			t.Fatalf("context.Cause(): got = '%v', want wraps = '%v'", context.Cause(ctx), baseErr)
+		}
+
+		merr, ok := err.(errs.MultiError)
+		if !ok || len(merr.Errors()) != 2 {
+			t.Fatalf("Wait(): got = '%v', want = '%s'", err, "2 errors")
+		}
+		cause, ok := errs.LookupAs[error](merr.Errors()[1], "cause")
+		if !ok || !errors.Is(cause, baseErr) {
+			t.Fatalf("LookupAs[error](cause): got = '%v, %t', want = '%v, %t'", cause, ok, baseErr, true)
+		}
+	})
+}
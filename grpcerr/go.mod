@@ -0,0 +1,16 @@
+module github.com/hemantjadon/errs/grpcerr
+
+go 1.25.0
+
+require (
+	github.com/hemantjadon/errs v0.0.0-20261017232713-1e2e63cdac38
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260904194346-d0f1323225a4
+	google.golang.org/grpc v1.84.0
+)
+
+require (
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/protobuf v1.36.12 // indirect
+)
@@ -0,0 +1,297 @@
+// Package grpcerr provides conversion between errors created by package errs
+// and gRPC statuses, so that errors retain their context across gRPC calls.
+package grpcerr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hemantjadon/errs"
+)
+
+// Domain is the domain of the errdetails.ErrorInfo attached to the statuses.
+const Domain = "github.com/hemantjadon/errs"
+
+// ToStatus converts the given error to a gRPC status, suitable to be sent to
+// any client.
+//
+// Errors which already carry a gRPC status are converted to that status. For
+// other errors, the code of the error is mapped to the status code, or taken
+// from any status error it wraps. Only the fields marked using errs.Public
+// among errs.AllFields of the error are carried, as the metadata of an
+// errdetails.ErrorInfo. Use ToStatusDebug to carry the whole error to trusted
+// peers.
+//
+// If the given error is nil, then a status with codes.OK is returned.
+func ToStatus(err error) *status.Status {
+	return toStatus(err, false)
+}
+
+// ToStatusDebug converts the given error to a gRPC status like ToStatus, but
+// carries all of errs.AllFields of the error as the metadata, and the messages
+// of the chain as the stack entries of an errdetails.DebugInfo, along with the
+// JSON encoding of the error, which is used by FromStatus to rebuild the
+// error.
+//
+// The status reveals the locations, messages and fields of all the errors
+// resulting to the error, including the ones boxed, so it must only be sent to
+// trusted peers.
+//
+// If the given error is nil, then a status with codes.OK is returned.
+func ToStatusDebug(err error) *status.Status {
+	return toStatus(err, true)
+}
+
+// Err converts the given error to an error carrying a gRPC status given by
+// ToStatus, suitable to be returned from gRPC handlers.
+//
+// If the given error is nil, then nil error is returned.
+func Err(err error) error {
+	if err == nil {
+		return nil
+	}
+	return ToStatus(err).Err()
+}
+
+// ErrDebug converts the given error to an error carrying a gRPC status given
+// by ToStatusDebug, suitable to be returned from gRPC handlers serving trusted
+// peers.
+//
+// If the given error is nil, then nil error is returned.
+func ErrDebug(err error) error {
+	if err == nil {
+		return nil
+	}
+	return ToStatusDebug(err).Err()
+}
+
+func toStatus(err error, debug bool) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	if serr, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return serr.GRPCStatus()
+	}
+	code := errs.CodeOf(err)
+	grpcCode := GRPCCode(code)
+	if len(code) == 0 {
+		grpcCode = status.Code(err)
+	}
+	st := status.New(grpcCode, err.Error())
+
+	reason := strings.ToUpper(string(code))
+	if len(reason) == 0 {
+		reason = strings.ToUpper(string(errs.Unknown))
+	}
+	info := errdetails.ErrorInfo{Reason: reason, Domain: Domain, Metadata: metadata(err, debug)}
+	if !debug {
+		if dst, derr := st.WithDetails(&info); derr == nil {
+			return dst
+		}
+		return st
+	}
+
+	debugInfo := errdetails.DebugInfo{StackEntries: chainMessages(err)}
+	if data, jerr := errs.MarshalJSON(err); jerr == nil {
+		debugInfo.Detail = string(data)
+	}
+
+	dst, derr := st.WithDetails(&info, &debugInfo)
+	if derr != nil {
+		return st
+	}
+	return dst
+}
+
+// FromStatus converts the given gRPC status to an error.
+//
+// Statuses created by ToStatusDebug are rebuilt into the original error,
+// retaining its fields, locations and chain. Other statuses, including the
+// ones created by ToStatus, are converted to an error with the status message,
+// classified with the code mapped from the status code, and carrying the
+// metadata of any errdetails.ErrorInfo as fields.
+//
+// If the given status is nil or has codes.OK, then nil error is returned.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	var err error
+	var fields []errs.Field
+	code := Code(st.Code())
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == Domain {
+				code = errs.Code(strings.ToLower(d.GetReason()))
+			}
+			for _, key := range sortedKeys(d.GetMetadata()) {
+				fields = append(fields, errs.F(key, d.GetMetadata()[key]))
+			}
+		case *errdetails.DebugInfo:
+			if len(d.GetDetail()) == 0 {
+				continue
+			}
+			if rerr, uerr := errs.UnmarshalJSON([]byte(d.GetDetail())); uerr == nil {
+				err = rerr
+			}
+		}
+	}
+	if err == nil {
+		msg := st.Message()
+		if len(msg) == 0 {
+			msg = st.Code().String()
+		}
+		err = errs.New(msg, fields...)
+	}
+	if code == errs.Unknown && len(errs.CodeOf(err)) == 0 {
+		return err
+	}
+	return errs.WithCode(err, code)
+}
+
+// FromError converts the given error carrying a gRPC status, like the ones
+// returned from gRPC clients, to an error using FromStatus.
+//
+// If the given error is nil, then nil error is returned.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, _ := status.FromError(err)
+	return FromStatus(st)
+}
+
+// GRPCCode gives the gRPC code corresponding to the given code. Codes which
+// are not well known are mapped to codes.Unknown.
+func GRPCCode(code errs.Code) codes.Code {
+	switch code {
+	case errs.Canceled:
+		return codes.Canceled
+	case errs.InvalidArgument:
+		return codes.InvalidArgument
+	case errs.DeadlineExceeded:
+		return codes.DeadlineExceeded
+	case errs.NotFound:
+		return codes.NotFound
+	case errs.AlreadyExists:
+		return codes.AlreadyExists
+	case errs.PermissionDenied:
+		return codes.PermissionDenied
+	case errs.ResourceExhausted:
+		return codes.ResourceExhausted
+	case errs.FailedPrecondition:
+		return codes.FailedPrecondition
+	case errs.Aborted:
+		return codes.Aborted
+	case errs.OutOfRange:
+		return codes.OutOfRange
+	case errs.Unimplemented:
+		return codes.Unimplemented
+	case errs.Internal:
+		return codes.Internal
+	case errs.Unavailable:
+		return codes.Unavailable
+	case errs.DataLoss:
+		return codes.DataLoss
+	case errs.Unauthenticated:
+		return codes.Unauthenticated
+	default:
+		return codes.Unknown
+	}
+}
+
+// Code gives the code corresponding to the given gRPC code.
+func Code(code codes.Code) errs.Code {
+	switch code {
+	case codes.OK:
+		return ""
+	case codes.Canceled:
+		return errs.Canceled
+	case codes.InvalidArgument:
+		return errs.InvalidArgument
+	case codes.DeadlineExceeded:
+		return errs.DeadlineExceeded
+	case codes.NotFound:
+		return errs.NotFound
+	case codes.AlreadyExists:
+		return errs.AlreadyExists
+	case codes.PermissionDenied:
+		return errs.PermissionDenied
+	case codes.ResourceExhausted:
+		return errs.ResourceExhausted
+	case codes.FailedPrecondition:
+		return errs.FailedPrecondition
+	case codes.Aborted:
+		return errs.Aborted
+	case codes.OutOfRange:
+		return errs.OutOfRange
+	case codes.Unimplemented:
+		return errs.Unimplemented
+	case codes.Internal:
+		return errs.Internal
+	case codes.Unavailable:
+		return errs.Unavailable
+	case codes.DataLoss:
+		return errs.DataLoss
+	case codes.Unauthenticated:
+		return errs.Unauthenticated
+	default:
+		return errs.Unknown
+	}
+}
+
+// layers gives the errors in the chain of the given error, or just the error
+// itself if it does not have a chain.
+func layers(err error) []error {
+	if cerr, ok := err.(errs.ChainError); ok {
+		return cerr.Chain()
+	}
+	return []error{err}
+}
+
+// metadata gives errs.AllFields of the given error as string values. Unless
+// all is set, only the public fields are given.
+func metadata(err error, all bool) map[string]string {
+	fields := errs.AllFields(err)
+	md := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if all || errs.IsPublic(field) {
+			md[field.Key()] = valueString(field)
+		}
+	}
+	return md
+}
+
+// valueString gives the string representation of the value of the given
+// field, formatting time values as RFC 3339.
+func valueString(f errs.Field) string {
+	if t, ok := f.Value().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(f.Value())
+}
+
+func chainMessages(err error) []string {
+	chain := layers(err)
+	msgs := make([]string, 0, len(chain))
+	for _, layer := range chain {
+		msgs = append(msgs, layer.Error())
+	}
+	return msgs
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,196 @@
+package grpcerr_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hemantjadon/errs"
+	"github.com/hemantjadon/errs/grpcerr"
+)
+
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	err   error
+	debug bool
+}
+
+func (s healthServer) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if s.debug {
+		return nil, grpcerr.ErrDebug(s.err)
+	}
+	return nil, grpcerr.Err(s.err)
+}
+
+func call(t *testing.T, err error, debug bool) error {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, healthServer{err: err, debug: debug})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, cerr := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if cerr != nil {
+		t.Fatalf("grpc.NewClient(): unexpected error: %v", cerr)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	_, rerr := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	return rerr
+}
+
+func TestToStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		st := grpcerr.ToStatus(nil)
+		if st.Code() != codes.OK {
+			t.Fatalf("Code(): got = '%s', want = '%s'", st.Code(), codes.OK)
+		}
+	})
+
+	t.Run("classified error", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.WithCode(errs.New("item not found", errs.F("item_id", 1)), errs.NotFound)
+
+		st := grpcerr.ToStatus(err)
+		if st.Code() != codes.NotFound {
+			t.Fatalf("Code(): got = '%s', want = '%s'", st.Code(), codes.NotFound)
+		}
+		if st.Message() != err.Error() {
+			t.Fatalf("Message(): got = '%s', want = '%s'", st.Message(), err.Error())
+		}
+		if len(st.Details()) != 1 {
+			t.Fatalf("len(Details()): got = %d, want = %d", len(st.Details()), 1)
+		}
+	})
+
+	t.Run("public fields", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("item not found", errs.Public(errs.String("item_id", "1")), errs.String("owner", "alice"))
+		err := errs.Box(baseErr, "get item", errs.String("user_id", "2"))
+
+		info, ok := grpcerr.ToStatus(err).Details()[0].(*errdetails.ErrorInfo)
+		if !ok {
+			t.Fatalf("Details()[0]: got = '%T', want = '%T'", grpcerr.ToStatus(err).Details()[0], info)
+		}
+		md := info.GetMetadata()
+		if len(md) != 1 || md["item_id"] != "1" {
+			t.Fatalf("Metadata: got = '%v', want = '%s'", md, "item_id:1")
+		}
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.WithCode(errs.New("item not found", errs.F("item_id", 1)), errs.NotFound)
+
+		st := grpcerr.ToStatusDebug(err)
+		if len(st.Details()) != 2 {
+			t.Fatalf("len(Details()): got = %d, want = %d", len(st.Details()), 2)
+		}
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		if info.GetMetadata()["item_id"] != "1" {
+			t.Fatalf("Metadata: got = '%v', want = '%s'", info.GetMetadata(), "item_id:1")
+		}
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("errs error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.WithCode(errs.New("item not found", errs.F("item_id", "1")), errs.NotFound)
+		want := errs.Box(baseErr, "get item", errs.F("user_id", "2"))
+
+		err := grpcerr.FromError(call(t, want, true))
+		if err == nil {
+			t.Fatalf("expected non-nil, got %v", err)
+		}
+		if err.Error() != want.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want.Error())
+		}
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+
+		chain := err.(errs.ChainError).Chain()
+		if len(chain) != 2 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(chain), 2)
+		}
+		fields := chain[1].(errs.FieldsError).Fields()
+		if len(fields) != 1 || fields[0].Key() != "item_id" || fields[0].Value() != "1" {
+			t.Fatalf("chain[1].Fields(): got = '%v', want = '%s'", fields, "item_id=1")
+		}
+
+		wfn, _, wline := want.(errs.LocationError).Location()
+		fn, _, line := err.(errs.LocationError).Location()
+		if fn != wfn || line != wline {
+			t.Fatalf("Location(): got = '%s:%d', want = '%s:%d'", fn, line, wfn, wline)
+		}
+		if errors.Unwrap(err) != nil {
+			t.Fatalf("Unwrap(): got = '%v', want = nil", errors.Unwrap(err))
+		}
+	})
+
+	t.Run("public", func(t *testing.T) {
+		t.Parallel()
+
+		want := errs.WithCode(errs.New("item not found", errs.Public(errs.String("item_id", "1")), errs.String("owner", "alice")), errs.NotFound)
+
+		err := grpcerr.FromError(call(t, want, false))
+		if err == nil {
+			t.Fatalf("expected non-nil, got %v", err)
+		}
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+		if val, ok := errs.Lookup(err, "item_id"); !ok || val != "1" {
+			t.Fatalf("Lookup(item_id): got = '%v, %t', want = '%s, %t'", val, ok, "1", true)
+		}
+		if errs.HasField(err, "owner") {
+			t.Fatalf("HasField(owner): got = %t, want = %t", true, false)
+		}
+	})
+
+	t.Run("foreign status", func(t *testing.T) {
+		t.Parallel()
+
+		err := grpcerr.FromError(call(t, status.Error(codes.Unavailable, "service unavailable"), false))
+		if err == nil {
+			t.Fatalf("expected non-nil, got %v", err)
+		}
+		if err.Error() != "service unavailable" {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), "service unavailable")
+		}
+		if code := errs.CodeOf(err); code != errs.Unavailable {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.Unavailable)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+// Package httperr provides helpers to report errors created by package errs
+// to net/http clients as problem details described by RFC 9457.
+package httperr
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hemantjadon/errs"
+)
+
+// ContentType is the media type of the problem details.
+const ContentType = "application/problem+json"
+
+// StatusClientClosedRequest is the non-standard status code used when the
+// client canceled the request.
+const StatusClientClosedRequest = 499
+
+// Problem defines the problem details of an error as described by RFC 9457.
+//
+// The code of the error and its public fields are encoded as extension
+// members of the problem details.
+type Problem struct {
+	Type     string
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+	Code     errs.Code
+	Fields   []errs.Field
+}
+
+// MarshalJSON gives the JSON encoding of the problem details.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	obj := make(map[string]interface{}, len(p.Fields)+6)
+	for _, field := range p.Fields {
+		obj[field.Key()] = jsonValue(field.Value())
+	}
+	if len(p.Code) != 0 {
+		obj["code"] = p.Code
+	}
+	obj["type"] = p.Type
+	obj["title"] = p.Title
+	obj["status"] = p.Status
+	if len(p.Detail) != 0 {
+		obj["detail"] = p.Detail
+	}
+	if len(p.Instance) != 0 {
+		obj["instance"] = p.Instance
+	}
+	return json.Marshal(obj)
+}
+
+// Public marks the given field as public, like errs.Public. Only public fields
+// of an error are exposed in its problem details.
+func Public(f errs.Field) errs.Field {
+	return errs.Public(f)
+}
+
+// StatusCode gives the http status code corresponding to the code of the
+// given error. Errors which are not classified are reported as internal
+// server errors.
+//
+// If the given error is nil, then http.StatusOK is returned.
+func StatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	switch errs.CodeOf(err) {
+	case errs.Canceled:
+		return StatusClientClosedRequest
+	case errs.InvalidArgument, errs.FailedPrecondition, errs.OutOfRange:
+		return http.StatusBadRequest
+	case errs.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case errs.NotFound:
+		return http.StatusNotFound
+	case errs.AlreadyExists, errs.Aborted:
+		return http.StatusConflict
+	case errs.PermissionDenied:
+		return http.StatusForbidden
+	case errs.Unauthenticated:
+		return http.StatusUnauthorized
+	case errs.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case errs.Unimplemented:
+		return http.StatusNotImplemented
+	case errs.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ProblemOf gives the problem details of the given error. Only the public
+// fields among errs.AllFields of the error are included.
+func ProblemOf(err error) Problem {
+	status := StatusCode(err)
+	title := http.StatusText(status)
+	if status == StatusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+	return Problem{
+		Type:   "about:blank",
+		Title:  title,
+		Status: status,
+		Code:   errs.CodeOf(err),
+		Fields: publicFields(err),
+	}
+}
+
+// WriteError writes the problem details of the given error as the response.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := ProblemOf(err)
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+	data, merr := json.Marshal(problem)
+	if merr != nil {
+		http.Error(w, problem.Title, problem.Status)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write(data)
+}
+
+// HandlerFunc defines a http handler which can fail with an error. The error
+// is written as the response using WriteError.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls h(w, r), writing the problem details of the error returned.
+func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h(w, r); err != nil {
+		WriteError(w, r, err)
+	}
+}
+
+// jsonValue gives the value to be encoded as JSON for the given field value.
+// Values rendered using their String method, like the ones of the fields
+// created by errs.Stringer, are encoded as their string representation unless
+// they define their own encoding.
+func jsonValue(val interface{}) interface{} {
+	switch val.(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+		return val
+	case fmt.Stringer:
+		return fmt.Sprint(val)
+	default:
+		return val
+	}
+}
+
+func publicFields(err error) []errs.Field {
+	var fields []errs.Field
+	for _, field := range errs.AllFields(err) {
+		if errs.IsPublic(field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
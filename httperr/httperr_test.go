@@ -0,0 +1,115 @@
+package httperr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+	"github.com/hemantjadon/errs/httperr"
+)
+
+type state struct{}
+
+func (state) String() string {
+	return "missing"
+}
+
+func TestStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil error", err: nil, want: http.StatusOK},
+		{name: "not classified", err: errs.New("error occurred"), want: http.StatusInternalServerError},
+		{name: "foreign error", err: errors.New("error occurred"), want: http.StatusInternalServerError},
+		{name: "NotFound", err: errs.WithCode(errs.New("error occurred"), errs.NotFound), want: http.StatusNotFound},
+		{name: "InvalidArgument", err: errs.WithCode(errs.New("error occurred"), errs.InvalidArgument), want: http.StatusBadRequest},
+		{name: "Canceled", err: errs.WithCode(errs.New("error occurred"), errs.Canceled), want: httperr.StatusClientClosedRequest},
+		{name: "wrapped", err: errs.Box(errs.WithCode(errs.New("error occurred"), errs.Unavailable), "boxed"), want: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := httperr.StatusCode(tt.err); got != tt.want {
+				t.Fatalf("StatusCode(): got = %d, want = %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no error", func(t *testing.T) {
+		t.Parallel()
+
+		h := httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("status: got = %d, want = %d", rec.Code, http.StatusNoContent)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		h := httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			err := errs.New("item not found", httperr.Public(errs.F("item_id", "1")), errs.F("table", "items"))
+			err = errs.WithCode(err, errs.NotFound)
+			return errs.Wrap(err, "get item", httperr.Public(errs.F("retry", false)), httperr.Public(errs.Stringer("state", state{})))
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status: got = %d, want = %d", rec.Code, http.StatusNotFound)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != httperr.ContentType {
+			t.Fatalf("Content-Type: got = '%s', want = '%s'", ct, httperr.ContentType)
+		}
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("json.Unmarshal(): unexpected error: %v", err)
+		}
+		if body["status"] != float64(http.StatusNotFound) {
+			t.Fatalf("status: got = '%v', want = '%d'", body["status"], http.StatusNotFound)
+		}
+		if body["title"] != http.StatusText(http.StatusNotFound) {
+			t.Fatalf("title: got = '%v', want = '%s'", body["title"], http.StatusText(http.StatusNotFound))
+		}
+		if body["instance"] != "/items/1" {
+			t.Fatalf("instance: got = '%v', want = '%s'", body["instance"], "/items/1")
+		}
+		if body["code"] != string(errs.NotFound) {
+			t.Fatalf("code: got = '%v', want = '%s'", body["code"], errs.NotFound)
+		}
+		if body["item_id"] != "1" {
+			t.Fatalf("item_id: got = '%v', want = '%s'", body["item_id"], "1")
+		}
+		if body["retry"] != false {
+			t.Fatalf("retry: got = '%v', want = '%v'", body["retry"], false)
+		}
+		if body["state"] != "missing" {
+			t.Fatalf("state: got = '%v', want = '%s'", body["state"], "missing")
+		}
+		if _, ok := body["table"]; ok {
+			t.Fatalf("table: got = '%v', want = '%s'", body["table"], "absent")
+		}
+	})
+}
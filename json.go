@@ -0,0 +1,291 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MarshalJSON gives the JSON encoding of the given error.
+//
+// The message, fields, location and chain of the error are encoded when the
+// error implements FieldsError, LocationError and ChainError respectively, so
+// that errors not created by this package can be encoded as well.
+//
+// If the given error is nil, then JSON null is returned.
+func MarshalJSON(err error) ([]byte, error) {
+	if err == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(errorJSON(err))
+}
+
+// UnmarshalJSON rebuilds an error from its JSON encoding as given by
+// MarshalJSON.
+//
+// The rebuilt error retains the messages, fields and locations of the encoded
+// error and of each error in its chain, along with whether each of them wraps
+// or boxes the next. Field values are decoded the same way as encoding/json
+// decodes into an interface{}.
+//
+// If JSON null is given, then nil error is returned.
+func UnmarshalJSON(data []byte) (error, error) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+	var je jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return nil, err
+	}
+	return je.build(), nil
+}
+
+// MarshalJSON gives the JSON encoding of the error.
+func (f fundamental) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON(&f))
+}
+
+// MarshalJSON gives the JSON encoding of the error.
+func (w wrapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON(&w))
+}
+
+// MarshalJSON gives the JSON encoding of the error.
+func (m multi) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON(&m))
+}
+
+type jsonError struct {
+	Error    string        `json:"error,omitempty"`
+	Message  string        `json:"message"`
+	Code     Code          `json:"code,omitempty"`
+	Fields   jsonFields    `json:"fields,omitempty"`
+	Location *jsonLocation `json:"location,omitempty"`
+	Boxed    bool          `json:"boxed,omitempty"`
+	Chain    []jsonError   `json:"chain,omitempty"`
+	Errors   []jsonError   `json:"errors,omitempty"`
+}
+
+type jsonLocation struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// build rebuilds the error represented by the JSON representation.
+func (je jsonError) build() error {
+	if len(je.Errors) != 0 {
+		errs := make([]error, 0, len(je.Errors))
+		for _, e := range je.Errors {
+			errs = append(errs, e.build())
+		}
+		if len(je.Code) != 0 {
+			// The code of the aggregated errors is kept by an empty layer.
+			return newWrapping(&fundamental{code: je.Code}, &multi{errs: errs}, true)
+		}
+		return &multi{errs: errs}
+	}
+	if len(je.Chain) == 0 {
+		return je.layer()
+	}
+	err := je.Chain[len(je.Chain)-1].build()
+	for idx := len(je.Chain) - 2; idx >= 0; idx-- {
+		err = newWrapping(je.Chain[idx].layer(), err, !je.Chain[idx].Boxed)
+	}
+	return err
+}
+
+// layer rebuilds the error represented by the JSON representation without
+// its chain.
+func (je jsonError) layer() *fundamental {
+	fdm := fundamental{msg: je.Message, fields: je.Fields, code: je.Code}
+	if je.Location != nil {
+		fdm.loc = Location{
+			Function: je.Location.Function,
+			File:     je.Location.File,
+			Line:     je.Location.Line,
+		}
+	}
+	return &fdm
+}
+
+func errorJSON(err error) jsonError {
+	je := layerJSON(err)
+	switch e := err.(type) {
+	case MultiError:
+		for _, c := range e.Errors() {
+			je.Errors = append(je.Errors, errorJSON(c))
+		}
+	case ChainError:
+		chain := chainJSON(err)
+		if w, ok := err.(*wrapping); ok && isEmptyLayer(w.fundamental) && len(chain) != 0 {
+			// The empty layer is described by the first error of the chain,
+			// which carries its code.
+			je = chain[0]
+			je.Boxed = false
+		}
+		je.Chain = chain
+	}
+	je.Error = err.Error()
+	return je
+}
+
+// layerJSON gives the JSON representation of the given error without its
+// chain.
+func layerJSON(err error) jsonError {
+	je := jsonError{Message: message(err)}
+	if cerr, ok := err.(CodeError); ok {
+		je.Code = cerr.Code()
+	}
+	if ferr, ok := err.(FieldsError); ok {
+		je.Fields = ferr.Fields()
+	}
+	if lerr, ok := err.(LocationError); ok {
+		fn, file, line := lerr.Location()
+		if len(fn) != 0 || len(file) != 0 {
+			je.Location = &jsonLocation{Function: fn, File: file, Line: line}
+		}
+	}
+	return je
+}
+
+// chainJSON gives the JSON representation of the chain of the given error.
+//
+// Errors created by this package are walked directly rather than through
+// Chain, so that whether each of them wraps or boxes the next is retained, and
+// aggregated errors are retained whole at the end of the chain. Empty layers
+// wrapping the next error are left out, with their code carried to the next
+// error.
+func chainJSON(err error) []jsonError {
+	var chain []jsonError
+	var code Code
+	add := func(je jsonError) {
+		if len(je.Code) == 0 {
+			je.Code = code
+		}
+		code = ""
+		chain = append(chain, je)
+	}
+	for {
+		w, ok := err.(*wrapping)
+		if !ok {
+			break
+		}
+		if w.wrapped && isEmptyLayer(w.fundamental) {
+			if len(code) == 0 {
+				code = w.code
+			}
+			err = w.err
+			continue
+		}
+		je := layerJSON(w.fundamental)
+		je.Boxed = !w.wrapped
+		add(je)
+		err = w.err
+	}
+	if _, ok := err.(MultiError); ok {
+		add(errorJSON(err))
+		return chain
+	}
+	if cerr, ok := err.(ChainError); ok {
+		for _, e := range cerr.Chain() {
+			add(layerJSON(e))
+		}
+		return chain
+	}
+	add(layerJSON(err))
+	return chain
+}
+
+// jsonFields encodes fields as a JSON object, retaining their order.
+type jsonFields []Field
+
+func (fs jsonFields) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for idx, field := range fs {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key())
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(valueJSON(field, time.RFC3339))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (fs *jsonFields) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		*fs = nil
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("errs: cannot unmarshal %v into fields", tok)
+	}
+	var fields jsonFields
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("errs: cannot unmarshal %v into field key", tok)
+		}
+		var val interface{}
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		fields = append(fields, F(key, val))
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	*fs = fields
+	return nil
+}
+
+// valueJSON gives the JSON encoding of the value of the given field. Time
+// values are encoded as strings formatted with the given layout, and the values
+// of the fields created by Stringer and values which cannot be encoded as JSON
+// as their string representation.
+func valueJSON(f Field, layout string) []byte {
+	val := f.Value()
+	switch val.(type) {
+	case time.Time, *time.Time:
+		val = string(appendAny(nil, val, layout))
+	default:
+		if f.typ == stringerType {
+			val = string(appendAny(nil, val, layout))
+		}
+	}
+	data, err := marshalJSON(val)
+	if err != nil {
+		data, _ = marshalJSON(string(appendAny(nil, val, layout)))
+	}
+	return data
+}
+
+// marshalJSON gives the JSON encoding of the given value like json.Marshal,
+// but without escaping the HTML characters, as the encoding is not meant to be
+// embedded in HTML.
+func marshalJSON(val interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(val); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
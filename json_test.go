@@ -0,0 +1,295 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hemantjadon/errs"
+)
+
+type jsonError struct {
+	Error    string                 `json:"error"`
+	Message  string                 `json:"message"`
+	Code     string                 `json:"code"`
+	Fields   map[string]interface{} `json:"fields"`
+	Location *struct {
+		Function string `json:"function"`
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+	} `json:"location"`
+	Boxed bool        `json:"boxed"`
+	Chain []jsonError `json:"chain"`
+}
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := errs.MarshalJSON(nil)
+		if err != nil {
+			t.Fatalf("MarshalJSON(): unexpected error: %v", err)
+		}
+		if string(data) != "null" {
+			t.Fatalf("MarshalJSON(): got = '%s', want = '%s'", data, "null")
+		}
+	})
+
+	t.Run("foreign error", func(t *testing.T) {
+		t.Parallel()
+
+		ferr := errors.New("error occurred")
+
+		data, err := errs.MarshalJSON(ferr)
+		if err != nil {
+			t.Fatalf("MarshalJSON(): unexpected error: %v", err)
+		}
+
+		var je jsonError
+		if err := json.Unmarshal(data, &je); err != nil {
+			t.Fatalf("json.Unmarshal(): unexpected error: %v", err)
+		}
+		if je.Message != ferr.Error() {
+			t.Fatalf("message: got = '%s', want = '%s'", je.Message, ferr.Error())
+		}
+		if je.Location != nil {
+			t.Fatalf("location: got = '%v', want = '%v'", je.Location, nil)
+		}
+		if len(je.Chain) != 0 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(je.Chain), 0)
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+
+		tt := time.Now()
+		ferr := errs.New("error occurred", errs.F("field1", "value1"), errs.F("field2", 10), errs.F("field3", tt), errs.F("field4", func() {}), errs.Stringer("field5", stringer{}))
+
+		data, err := json.Marshal(ferr)
+		if err != nil {
+			t.Fatalf("json.Marshal(): unexpected error: %v", err)
+		}
+
+		var je jsonError
+		if err := json.Unmarshal(data, &je); err != nil {
+			t.Fatalf("json.Unmarshal(): unexpected error: %v", err)
+		}
+		if je.Message != "error occurred" {
+			t.Fatalf("message: got = '%s', want = '%s'", je.Message, "error occurred")
+		}
+		if je.Error != ferr.Error() {
+			t.Fatalf("error: got = '%s', want = '%s'", je.Error, ferr.Error())
+		}
+		if je.Fields["field1"] != "value1" {
+			t.Fatalf("fields[field1]: got = '%v', want = '%v'", je.Fields["field1"], "value1")
+		}
+		if je.Fields["field2"] != float64(10) {
+			t.Fatalf("fields[field2]: got = '%v', want = '%v'", je.Fields["field2"], 10)
+		}
+		if je.Fields["field3"] != tt.Format(time.RFC3339) {
+			t.Fatalf("fields[field3]: got = '%v', want = '%v'", je.Fields["field3"], tt.Format(time.RFC3339))
+		}
+		if _, ok := je.Fields["field4"].(string); !ok {
+			t.Fatalf("fields[field4]: got type = '%T', want = 'string'", je.Fields["field4"])
+		}
+		if je.Fields["field5"] != "stringer" {
+			t.Fatalf("fields[field5]: got = '%v', want = '%v'", je.Fields["field5"], "stringer")
+		}
+		if je.Location == nil || je.Location.Line == 0 {
+			t.Fatalf("location: got = '%v', want = '%s'", je.Location, "non-empty")
+		}
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		innerErr := errs.Box(baseErr, "inner error", errs.F("field1", "value1"))
+		ferr := errs.Wrap(innerErr, "error occurred", errs.F("field2", "value2"))
+
+		data, err := json.Marshal(ferr)
+		if err != nil {
+			t.Fatalf("json.Marshal(): unexpected error: %v", err)
+		}
+
+		var je jsonError
+		if err := json.Unmarshal(data, &je); err != nil {
+			t.Fatalf("json.Unmarshal(): unexpected error: %v", err)
+		}
+		if je.Message != "error occurred" {
+			t.Fatalf("message: got = '%s', want = '%s'", je.Message, "error occurred")
+		}
+		if je.Error != ferr.Error() {
+			t.Fatalf("error: got = '%s', want = '%s'", je.Error, ferr.Error())
+		}
+		if len(je.Chain) != 3 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(je.Chain), 3)
+		}
+		if je.Chain[0].Message != "error occurred" || je.Chain[0].Boxed {
+			t.Fatalf("chain[0]: got = '%v', want = '%s'", je.Chain[0], "wrapped error occurred")
+		}
+		if je.Chain[1].Message != "inner error" || !je.Chain[1].Boxed {
+			t.Fatalf("chain[1]: got = '%v', want = '%s'", je.Chain[1], "boxed inner error")
+		}
+		if je.Chain[1].Fields["field1"] != "value1" {
+			t.Fatalf("chain[1].fields[field1]: got = '%v', want = '%v'", je.Chain[1].Fields["field1"], "value1")
+		}
+		if je.Chain[2].Message != baseErr.Error() {
+			t.Fatalf("chain[2].message: got = '%s', want = '%s'", je.Chain[2].Message, baseErr.Error())
+		}
+	})
+
+	t.Run("empty layer", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := errs.Wrap(errs.WithCode(baseErr, errs.NotFound), "error occurred")
+
+		data, merr := errs.MarshalJSON(err)
+		if merr != nil {
+			t.Fatalf("MarshalJSON(): unexpected error: %v", merr)
+		}
+		var je jsonError
+		if uerr := json.Unmarshal(data, &je); uerr != nil {
+			t.Fatalf("json.Unmarshal(): unexpected error: %v", uerr)
+		}
+		if len(je.Chain) != 2 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(je.Chain), 2)
+		}
+		if je.Chain[1].Message != baseErr.Error() || je.Chain[1].Code != string(errs.NotFound) {
+			t.Fatalf("chain[1]: got = '%s, %s', want = '%s, %s'", je.Chain[1].Message, je.Chain[1].Code, baseErr.Error(), errs.NotFound)
+		}
+
+		rebuilt, uerr := errs.UnmarshalJSON(data)
+		if uerr != nil {
+			t.Fatalf("UnmarshalJSON(): unexpected error: %v", uerr)
+		}
+		if rebuilt.Error() != err.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", rebuilt.Error(), err.Error())
+		}
+		if code := errs.CodeOf(rebuilt); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+	})
+
+	t.Run("empty outermost layer", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		data, merr := errs.MarshalJSON(errs.WithCode(baseErr, errs.NotFound))
+		if merr != nil {
+			t.Fatalf("MarshalJSON(): unexpected error: %v", merr)
+		}
+		var je jsonError
+		if uerr := json.Unmarshal(data, &je); uerr != nil {
+			t.Fatalf("json.Unmarshal(): unexpected error: %v", uerr)
+		}
+		if je.Message != baseErr.Error() || je.Code != string(errs.NotFound) {
+			t.Fatalf("top: got = '%s, %s', want = '%s, %s'", je.Message, je.Code, baseErr.Error(), errs.NotFound)
+		}
+	})
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		err, uerr := errs.UnmarshalJSON([]byte("null"))
+		if uerr != nil {
+			t.Fatalf("UnmarshalJSON(): unexpected error: %v", uerr)
+		}
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, uerr := errs.UnmarshalJSON([]byte(`{"message": "error occurred", "fields": []}`))
+		if uerr == nil {
+			t.Fatalf("UnmarshalJSON(): expected error, got nil")
+		}
+	})
+
+	t.Run("fundamental", func(t *testing.T) {
+		t.Parallel()
+
+		ferr := errs.New("error occurred", errs.F("field1", "value1"), errs.F("field2", 10))
+		data, err := json.Marshal(ferr)
+		if err != nil {
+			t.Fatalf("json.Marshal(): unexpected error: %v", err)
+		}
+
+		err, uerr := errs.UnmarshalJSON(data)
+		if uerr != nil {
+			t.Fatalf("UnmarshalJSON(): unexpected error: %v", uerr)
+		}
+		if err.Error() != ferr.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), ferr.Error())
+		}
+
+		fields := err.(errs.FieldsError).Fields()
+		if len(fields) != 2 {
+			t.Fatalf("len(fields): got = %d, want = %d", len(fields), 2)
+		}
+		if fields[0].Key() != "field1" || fields[0].Value() != "value1" {
+			t.Fatalf("fields[0]: got = '%s=%v', want = '%s=%v'", fields[0].Key(), fields[0].Value(), "field1", "value1")
+		}
+		if fields[1].Key() != "field2" || fields[1].Value() != float64(10) {
+			t.Fatalf("fields[1]: got = '%s=%v', want = '%s=%v'", fields[1].Key(), fields[1].Value(), "field2", 10)
+		}
+
+		wfn, wfile, wline := ferr.(errs.LocationError).Location()
+		fn, file, line := err.(errs.LocationError).Location()
+		if fn != wfn || file != wfile || line != wline {
+			t.Fatalf("Location(): got = '%s %s:%d', want = '%s %s:%d'", fn, file, line, wfn, wfile, wline)
+		}
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		innerErr := errs.Box(baseErr, "inner error", errs.F("field1", "value1"))
+		ferr := errs.Wrap(innerErr, "error occurred", errs.F("field2", "value2"))
+
+		data, err := json.Marshal(ferr)
+		if err != nil {
+			t.Fatalf("json.Marshal(): unexpected error: %v", err)
+		}
+
+		err, uerr := errs.UnmarshalJSON(data)
+		if uerr != nil {
+			t.Fatalf("UnmarshalJSON(): unexpected error: %v", uerr)
+		}
+		if err.Error() != ferr.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), ferr.Error())
+		}
+
+		chain := err.(errs.ChainError).Chain()
+		if len(chain) != 3 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(chain), 3)
+		}
+		if chain[2].Error() != baseErr.Error() {
+			t.Fatalf("chain[2].Error(): got = '%s', want = '%s'", chain[2].Error(), baseErr.Error())
+		}
+
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			t.Fatalf("Unwrap(): got = nil, want = 'inner error'")
+		}
+		if inner.Error() != innerErr.Error() {
+			t.Fatalf("Unwrap().Error(): got = '%s', want = '%s'", inner.Error(), innerErr.Error())
+		}
+		if errors.Unwrap(inner) != nil {
+			t.Fatalf("Unwrap(Unwrap()): got = '%v', want = nil", errors.Unwrap(inner))
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package errs
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Location defines a source-code location.
+type Location struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// String gives the location as the short function name followed by the file
+// and the line, like "errs.New (github.com/hemantjadon/errs/errs.go:94)".
+//
+// If the location is empty, then empty string is returned.
+func (l Location) String() string {
+	if len(l.Function) == 0 && len(l.File) == 0 {
+		return ""
+	}
+	return l.ShortFunction() + " (" + l.File + ":" + strconv.Itoa(l.Line) + ")"
+}
+
+// ShortFunction gives the function name without the import path of its
+// package, like "errs.New" for "github.com/hemantjadon/errs.New".
+func (l Location) ShortFunction() string {
+	return l.Function[strings.LastIndexByte(l.Function, '/')+1:]
+}
+
+// LocationOf gives the location of the outermost error, in the order of Walk,
+// resulting to the given error which has one.
+func LocationOf(err error) (Location, bool) {
+	var loc Location
+	Walk(err, func(n *Node) bool {
+		lerr, ok := n.Err.(LocationError)
+		if !ok {
+			return true
+		}
+		fn, file, line := lerr.Location()
+		if len(fn) == 0 && len(file) == 0 {
+			return true
+		}
+		loc = Location{Function: fn, File: file, Line: line}
+		return false
+	})
+	return loc, len(loc.Function) != 0 || len(loc.File) != 0
+}
+
+var trimPrefixes atomic.Pointer[[]string]
+
+// SetTrimPrefixes sets the package wide prefixes trimmed from the files of the
+// locations and the stack traces of the errors. The first matching prefix is
+// trimmed. Setting nil prefixes restores the DefaultTrimPrefixes, and setting
+// empty prefixes disables the trimming.
+//
+// Files of the packages of the modules the program is built with, not matching
+// any of the prefixes, are trimmed to the import path of their package, so
+// that they do not depend on where the modules are located. Files of the main
+// package, whose import path is not known, are kept as is.
+//
+// Files of programs built with -trimpath are already trimmed, so they are kept
+// as is.
+func SetTrimPrefixes(prefixes []string) {
+	if prefixes == nil {
+		trimPrefixes.Store(nil)
+		return
+	}
+	prefixes = append([]string{}, prefixes...)
+	trimPrefixes.Store(&prefixes)
+}
+
+// DefaultTrimPrefixes gives the default prefixes trimmed from the files of the
+// locations, which are the source directory of GOROOT, the module cache and
+// the source directories of GOPATH, making the files like the ones of programs
+// built with -trimpath.
+func DefaultTrimPrefixes() []string {
+	return append([]string{}, defaultTrimPrefixes()...)
+}
+
+var defaultTrimPrefixes = sync.OnceValue(func() []string {
+	var prefixes []string
+	// The source directory of GOROOT is found from the file of a function of
+	// the standard library, which also works when GOROOT is not set.
+	pc := reflect.ValueOf(strings.Cut).Pointer()
+	if file, _ := runtime.FuncForPC(pc).FileLine(pc); filepath.IsAbs(file) {
+		prefixes = append(prefixes, strings.TrimSuffix(file, "strings/strings.go"))
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if len(gopath) == 0 {
+		if home, err := os.UserHomeDir(); err == nil {
+			gopath = filepath.Join(home, "go")
+		}
+	}
+	modcache := os.Getenv("GOMODCACHE")
+	if gopaths := filepath.SplitList(gopath); len(modcache) == 0 && len(gopaths) != 0 {
+		modcache = filepath.Join(gopaths[0], "pkg", "mod")
+	}
+	if len(modcache) != 0 {
+		prefixes = append(prefixes, filepath.ToSlash(modcache)+"/")
+	}
+	for _, dir := range filepath.SplitList(gopath) {
+		prefixes = append(prefixes, filepath.ToSlash(filepath.Join(dir, "src"))+"/")
+	}
+	return prefixes
+})
+
+type buildInfo struct {
+	trimpath bool
+	main     string
+	modules  []string
+}
+
+var readBuildInfo = sync.OnceValue(func() buildInfo {
+	var info buildInfo
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.main = bi.Main.Path
+	if len(bi.Main.Path) != 0 {
+		info.modules = append(info.modules, bi.Main.Path)
+	}
+	for _, dep := range bi.Deps {
+		info.modules = append(info.modules, dep.Path)
+	}
+	for _, s := range bi.Settings {
+		if s.Key == "-trimpath" && s.Value == "true" {
+			info.trimpath = true
+		}
+	}
+	return info
+})
+
+// trimFile trims the given file of the given function as set by
+// SetTrimPrefixes.
+func trimFile(fn string, file string) string {
+	info := readBuildInfo()
+	if info.trimpath || len(file) == 0 {
+		return file
+	}
+	prefixes := defaultTrimPrefixes()
+	if p := trimPrefixes.Load(); p != nil {
+		prefixes = *p
+		if len(prefixes) == 0 {
+			return file
+		}
+	}
+	for _, prefix := range prefixes {
+		if len(prefix) != 0 && strings.HasPrefix(file, prefix) {
+			return strings.TrimPrefix(file, prefix)
+		}
+	}
+	return moduleFile(info, fn, file)
+}
+
+// moduleFile gives the given file of the given function as the import path of
+// the package of the function followed by the name of the file, if the
+// package belongs to one of the modules the program is built with. Otherwise,
+// including for the main package whose import path is not known, the file is
+// returned as is.
+func moduleFile(info buildInfo, fn string, file string) string {
+	pkg := packagePath(fn)
+	if pkg == "main" {
+		return file
+	}
+	dir := path.Dir(filepath.ToSlash(file))
+	pkg = strings.TrimSuffix(pkg, "_test")
+
+	var mod string
+	for _, m := range info.modules {
+		if (pkg == m || strings.HasPrefix(pkg, m+"/")) && len(m) > len(mod) {
+			mod = m
+		}
+	}
+	if len(mod) == 0 || !strings.HasSuffix(dir, pkg[len(mod):]) {
+		return file
+	}
+	return pkg + "/" + path.Base(file)
+}
+
+// packagePath gives the import path of the package of the given fully
+// qualified function name.
+func packagePath(fn string) string {
+	slash := strings.LastIndexByte(fn, '/')
+	dot := strings.IndexByte(fn[slash+1:], '.')
+	if dot < 0 {
+		return ""
+	}
+	// Dots in the last element of the import path are escaped.
+	return strings.ReplaceAll(fn[:slash+1+dot], "%2e", ".")
+}
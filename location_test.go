@@ -0,0 +1,139 @@
+package errs_test
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			loc  errs.Location
+			want string
+		}{
+			{name: "empty", loc: errs.Location{}, want: ""},
+			{
+				name: "function",
+				loc:  errs.Location{Function: "github.com/hemantjadon/errs.New", File: "github.com/hemantjadon/errs/errs.go", Line: 94},
+				want: "errs.New (github.com/hemantjadon/errs/errs.go:94)",
+			},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				if got := tt.loc.String(); got != tt.want {
+					t.Fatalf("String(): got = '%s', want = '%s'", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("ShortFunction", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			fn   string
+			want string
+		}{
+			{fn: "main.main", want: "main.main"},
+			{fn: "github.com/hemantjadon/errs.New", want: "errs.New"},
+			{fn: "github.com/hemantjadon/errs.(*Group).Go.func1", want: "errs.(*Group).Go.func1"},
+		}
+		for _, tt := range tests {
+			loc := errs.Location{Function: tt.fn}
+			if got := loc.ShortFunction(); got != tt.want {
+				t.Fatalf("ShortFunction(): got = '%s', want = '%s'", got, tt.want)
+			}
+		}
+	})
+}
+
+func TestLocationOf(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no location", func(t *testing.T) {
+		t.Parallel()
+
+		if loc, ok := errs.LocationOf(errors.New("error occurred")); ok {
+			t.Fatalf("LocationOf(): got = '%v, %t', want = '%v, %t'", loc, ok, errs.Location{}, false)
+		}
+	})
+
+	t.Run("outermost", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, line, _ := runtime.Caller(0)
+		err := errs.WithCode(errors.New("error occurred"), errs.Internal)
+
+		loc, ok := errs.LocationOf(err)
+		if !ok {
+			t.Fatalf("LocationOf(): got = %t, want = %t", ok, true)
+		}
+		if loc.Line != line+1 {
+			t.Fatalf("Line: got = %d, want = %d", loc.Line, line+1)
+		}
+		if want := "github.com/hemantjadon/errs/location_test.go"; loc.File != want {
+			t.Fatalf("File: got = '%s', want = '%s'", loc.File, want)
+		}
+		if want := "errs_test.TestLocationOf.func2"; loc.ShortFunction() != want {
+			t.Fatalf("ShortFunction(): got = '%s', want = '%s'", loc.ShortFunction(), want)
+		}
+	})
+}
+
+func TestSetTrimPrefixes(t *testing.T) {
+	defer errs.SetTrimPrefixes(nil)
+
+	err := errs.New("error occurred")
+	_, raw, _, _ := runtime.Caller(0)
+
+	errs.SetTrimPrefixes([]string{})
+	if _, file, _ := err.(errs.LocationError).Location(); file != raw {
+		t.Fatalf("Location() file: got = '%s', want = '%s'", file, raw)
+	}
+
+	if !filepath.IsAbs(raw) {
+		t.Skipf("file: got = '%s', want = 'absolute', built with -trimpath", raw)
+	}
+	prefix := raw[:strings.LastIndexByte(raw, '/')+1]
+	errs.SetTrimPrefixes([]string{prefix})
+	if _, file, _ := err.(errs.LocationError).Location(); file != "location_test.go" {
+		t.Fatalf("Location() file: got = '%s', want = '%s'", file, "location_test.go")
+	}
+	if frames := err.(errs.StackError).StackTrace(); frames[0].File != "location_test.go" {
+		t.Fatalf("frames[0].File: got = '%s', want = '%s'", frames[0].File, "location_test.go")
+	}
+
+	errs.SetTrimPrefixes(nil)
+	if _, file, _ := err.(errs.LocationError).Location(); file != "github.com/hemantjadon/errs/location_test.go" {
+		t.Fatalf("Location() file: got = '%s', want = '%s'", file, "github.com/hemantjadon/errs/location_test.go")
+	}
+}
+
+func TestDefaultTrimPrefixes(t *testing.T) {
+	t.Parallel()
+
+	frames := errs.New("error occurred").(errs.StackError).StackTrace()
+	last := frames[len(frames)-1]
+	if !strings.HasPrefix(last.Function, "runtime.") {
+		t.Skipf("last frame: got = '%s', want = 'runtime function'", last.Function)
+	}
+	if !strings.HasPrefix(last.File, "runtime/") {
+		t.Fatalf("last frame file: got = '%s', want prefix = '%s'", last.File, "runtime/")
+	}
+	if len(errs.DefaultTrimPrefixes()) == 0 {
+		t.Fatalf("len(DefaultTrimPrefixes()): got = %d, want = '%s'", 0, "non-zero")
+	}
+}
@@ -0,0 +1,97 @@
+package errs
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Append appends the given errors to the given error, giving an error which
+// aggregates all of them. Nil errors are ignored, and errors which are
+// themselves aggregates created by Append are flattened.
+//
+// The resulting error implements MultiError and ChainError, and can be
+// unwrapped to each of the aggregated errors using errors.Is and errors.As.
+//
+// If all the given errors are nil, then nil error is returned. If only one of
+// the given errors is non-nil, then it is returned as is.
+func Append(err error, others ...error) error {
+	var agg []error
+	for _, e := range append([]error{err}, others...) {
+		switch e := e.(type) {
+		case nil:
+			continue
+		case *multi:
+			agg = append(agg, e.errs...)
+		default:
+			agg = append(agg, e)
+		}
+	}
+	switch len(agg) {
+	case 0:
+		return nil
+	case 1:
+		return agg[0]
+	default:
+		return &multi{errs: agg}
+	}
+}
+
+type multi struct {
+	errs []error
+}
+
+func (m multi) Error() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(len(m.errs)))
+	sb.WriteString(" errors occurred:")
+	for idx, err := range m.errs {
+		sb.WriteString("\n\t")
+		sb.WriteString(strconv.Itoa(idx + 1))
+		sb.WriteString(". ")
+		sb.WriteString(strings.ReplaceAll(err.Error(), "\n", "\n\t"))
+	}
+	return sb.String()
+}
+
+// Errors gives the errors aggregated by the error.
+func (m multi) Errors() []error {
+	errs := make([]error, 0, len(m.errs))
+	errs = append(errs, m.errs...)
+	return errs
+}
+
+// Unwrap gives the errors aggregated by the error.
+func (m multi) Unwrap() []error {
+	return m.Errors()
+}
+
+// Chain gives the chains of each of the errors aggregated by the error, one
+// after the other.
+func (m multi) Chain() []error {
+	var chn []error
+	for _, err := range m.errs {
+		chn = append(chn, chainOf(err)...)
+	}
+	return chn
+}
+
+// Format formats the error according to the fmt.Formatter interface.
+//
+//	%s    error string
+//	%v    same as %s
+//	%q    double-quoted error string
+//	%+v   numbered list of the aggregated errors, each formatted with %+v
+func (m multi) Format(s fmt.State, verb rune) {
+	if verb != 'v' || !s.Flag('+') {
+		format(s, verb, m, nil)
+		return
+	}
+	io.WriteString(s, strconv.Itoa(len(m.errs)))
+	io.WriteString(s, " errors occurred:")
+	for idx, err := range m.errs {
+		fmt.Fprintf(s, "\n\t%d. ", idx+1)
+		io.WriteString(s, strings.ReplaceAll(fmt.Sprintf("%+v", err), "\n", "\n\t"))
+	}
+}
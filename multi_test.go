@@ -0,0 +1,171 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestAppend(t *testing.T) {
+	t.Parallel()
+
+	err1 := errs.New("error one", errs.F("field1", "value1"))
+	err2 := errs.Wrap(errors.New("base error"), "error two")
+	err3 := errors.New("error three")
+
+	t.Run("nil errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Append(nil, nil, nil)
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("single error", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Append(nil, err1, nil)
+		if err != err1 {
+			t.Fatalf("got = '%v', want = '%v'", err, err1)
+		}
+	})
+
+	t.Run("multiple errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Append(err1, err2)
+
+		merr, ok := err.(errs.MultiError)
+		if !ok {
+			t.Fatalf("got type = '%T', want = 'MultiError'", err)
+		}
+		if len(merr.Errors()) != 2 {
+			t.Fatalf("len(Errors()): got = %d, want = %d", len(merr.Errors()), 2)
+		}
+
+		want := "2 errors occurred:\n\t1. " + err1.Error() + "\n\t2. " + err2.Error()
+		if err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+		if !errors.Is(err, err1) {
+			t.Fatalf("should wrap error one")
+		}
+		if !errors.Is(err, err2) {
+			t.Fatalf("should wrap error two")
+		}
+	})
+
+	t.Run("flattened", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Append(errs.Append(err1, err2), err3)
+
+		aggregated := err.(errs.MultiError).Errors()
+		if len(aggregated) != 3 {
+			t.Fatalf("len(Errors()): got = %d, want = %d", len(aggregated), 3)
+		}
+		if aggregated[2] != err3 {
+			t.Fatalf("Errors()[2]: got = '%v', want = '%v'", aggregated[2], err3)
+		}
+	})
+
+	t.Run("ChainError", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Append(err1, err2, err3)
+
+		chain := err.(errs.ChainError).Chain()
+		if len(chain) != 4 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(chain), 4)
+		}
+		if _, ok := chain[0].(errs.FieldsError); !ok {
+			t.Fatalf("chain[0]: got type = '%T', want = 'FieldsError'", chain[0])
+		}
+		if _, ok := chain[1].(errs.LocationError); !ok {
+			t.Fatalf("chain[1]: got type = '%T', want = 'LocationError'", chain[1])
+		}
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.Append(err1, err2), "error occurred")
+
+		chain := err.(errs.ChainError).Chain()
+		if len(chain) != 2 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(chain), 2)
+		}
+		if _, ok := chain[1].(errs.MultiError); !ok {
+			t.Fatalf("chain[1]: got type = '%T', want = 'MultiError'", chain[1])
+		}
+		if !errors.Is(err, err2) {
+			t.Fatalf("should wrap error two")
+		}
+	})
+
+	t.Run("CodeOf", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.Append(err1, errs.WithCode(err3, errs.NotFound)), "error occurred")
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+	})
+
+	t.Run("%+v", func(t *testing.T) {
+		t.Parallel()
+
+		got := fmt.Sprintf("%+v", errs.Append(err1, err3))
+		if !strings.HasPrefix(got, "2 errors occurred:\n\t1. "+err1.Error()+"\n\t\t") {
+			t.Fatalf("got = '%s', want prefix = '%s'", got, "2 errors occurred:\n\t1. "+err1.Error())
+		}
+		if !strings.HasSuffix(got, "\n\t2. "+err3.Error()) {
+			t.Fatalf("got = '%s', want suffix = '%s'", got, "\n\t2. "+err3.Error())
+		}
+	})
+
+	t.Run("%+v Wrap", func(t *testing.T) {
+		t.Parallel()
+
+		multiErr := errs.Append(err1, err3)
+		got := fmt.Sprintf("%+v", errs.Wrap(multiErr, "error occurred"))
+		want := fmt.Sprintf("%+v", multiErr)
+		if !strings.HasSuffix(got, "\n"+want) {
+			t.Fatalf("got = '%s', want suffix = '%s'", got, "\n"+want)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		want := errs.Box(errs.Append(err1, err2), "error occurred")
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("json.Marshal(): unexpected error: %v", err)
+		}
+
+		err, uerr := errs.UnmarshalJSON(data)
+		if uerr != nil {
+			t.Fatalf("UnmarshalJSON(): unexpected error: %v", uerr)
+		}
+		if err.Error() != want.Error() {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want.Error())
+		}
+		chain := err.(errs.ChainError).Chain()
+		if len(chain) != 2 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(chain), 2)
+		}
+		merr, ok := chain[1].(errs.MultiError)
+		if !ok {
+			t.Fatalf("chain[1]: got type = '%T', want = 'MultiError'", chain[1])
+		}
+		if len(merr.Errors()) != 2 {
+			t.Fatalf("len(Errors()): got = %d, want = %d", len(merr.Errors()), 2)
+		}
+	})
+}
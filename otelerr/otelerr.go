@@ -0,0 +1,146 @@
+// Package otelerr provides recording of errors created by package errs on
+// OpenTelemetry spans.
+package otelerr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hemantjadon/errs"
+)
+
+// RecordError records the given error on the given span.
+//
+// The error is recorded as an "exception" event, with the Error string as the
+// "exception.message", the type of the error as the "exception.type", and the
+// stack trace of the innermost error carrying one as the
+// "exception.stacktrace". The errs.AllFields of the error are set as attributes
+// of the span, typed after the values of the fields, along with the code of the
+// error as "error.type".
+//
+// The status of the span is set to codes.Error, unless the error is classified
+// with a code which is caused by the client, like errs.NotFound or
+// errs.InvalidArgument, as such errors are not failures of the operation
+// traced by the span.
+//
+// If the given error is nil, or the span is not recording, then nothing is
+// recorded.
+func RecordError(span trace.Span, err error, opts ...trace.EventOption) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("exception.type", typeOf(err)),
+		attribute.String("exception.message", err.Error()),
+	}
+	if stacktrace := stackTrace(err); len(stacktrace) != 0 {
+		attrs = append(attrs, attribute.String("exception.stacktrace", stacktrace))
+	}
+	span.AddEvent("exception", append(opts, trace.WithAttributes(attrs...))...)
+
+	fields := errs.AllFields(err)
+	attrs = make([]attribute.KeyValue, 0, len(fields)+1)
+	for _, field := range fields {
+		attrs = append(attrs, Attribute(field))
+	}
+	code := errs.CodeOf(err)
+	if len(code) != 0 {
+		attrs = append(attrs, attribute.String("error.type", string(code)))
+	}
+	span.SetAttributes(attrs...)
+
+	if !clientCode(code) {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// Attribute converts the given field to an attribute, typed after the value of
+// the field. Time values are converted to strings formatted as RFC 3339, and
+// values of other types without a matching attribute type to strings in their
+// %v format.
+func Attribute(f errs.Field) attribute.KeyValue {
+	switch val := f.Value().(type) {
+	case string:
+		return attribute.String(f.Key(), val)
+	case int:
+		return attribute.Int(f.Key(), val)
+	case int64:
+		return attribute.Int64(f.Key(), val)
+	case float64:
+		return attribute.Float64(f.Key(), val)
+	case bool:
+		return attribute.Bool(f.Key(), val)
+	case time.Duration:
+		return attribute.String(f.Key(), val.String())
+	case time.Time:
+		return attribute.String(f.Key(), val.Format(time.RFC3339))
+	case []string:
+		return attribute.StringSlice(f.Key(), val)
+	case []int:
+		return attribute.IntSlice(f.Key(), val)
+	case []int64:
+		return attribute.Int64Slice(f.Key(), val)
+	case []float64:
+		return attribute.Float64Slice(f.Key(), val)
+	case []bool:
+		return attribute.BoolSlice(f.Key(), val)
+	default:
+		return attribute.String(f.Key(), fmt.Sprint(val))
+	}
+}
+
+// clientCode reports whether the given code classifies errors caused by the
+// client rather than by the server.
+func clientCode(code errs.Code) bool {
+	switch code {
+	case errs.Canceled, errs.InvalidArgument, errs.NotFound, errs.AlreadyExists,
+		errs.PermissionDenied, errs.FailedPrecondition, errs.OutOfRange, errs.Unauthenticated:
+		return true
+	default:
+		return false
+	}
+}
+
+// typeOf gives the type name of the given error, like "*errors.errorString".
+func typeOf(err error) string {
+	typ := reflect.TypeOf(err)
+	if len(typ.PkgPath()) == 0 && typ.Kind() == reflect.Pointer {
+		return "*" + typ.Elem().PkgPath() + "." + typ.Elem().Name()
+	}
+	return typ.PkgPath() + "." + typ.Name()
+}
+
+// stackTrace gives the stack trace of the innermost error resulting to the
+// given error which carries one, formatted like the traces of panics. Errors
+// carrying only a location, like the ones rebuilt by errs.UnmarshalJSON, give
+// a single frame.
+func stackTrace(err error) string {
+	var frames []errs.Frame
+	errs.Walk(err, func(n *errs.Node) bool {
+		if serr, ok := n.Err.(errs.StackError); ok {
+			if trace := serr.StackTrace(); len(trace) != 0 {
+				frames = trace
+				return true
+			}
+		}
+		if lerr, ok := n.Err.(errs.LocationError); ok {
+			if fn, file, line := lerr.Location(); len(fn) != 0 {
+				frames = []errs.Frame{{Function: fn, File: file, Line: line}}
+			}
+		}
+		return true
+	})
+
+	var sb strings.Builder
+	for _, frame := range frames {
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+	return sb.String()
+}
@@ -0,0 +1,162 @@
+package otelerr_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hemantjadon/errs"
+	"github.com/hemantjadon/errs/otelerr"
+)
+
+func record(t *testing.T, err error) tracetest.SpanStub {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	_, span := provider.Tracer("otelerr_test").Start(context.Background(), "operation")
+	otelerr.RecordError(span, err)
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("len(spans): got = %d, want = %d", len(spans), 1)
+	}
+	return spans[0]
+}
+
+func attrOf(attrs []attribute.KeyValue, key string) (attribute.Value, bool) {
+	for _, attr := range attrs {
+		if string(attr.Key) == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestRecordError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		span := record(t, nil)
+		if len(span.Events) != 0 {
+			t.Fatalf("len(Events): got = %d, want = %d", len(span.Events), 0)
+		}
+		if span.Status.Code != codes.Unset {
+			t.Fatalf("Status.Code: got = '%s', want = '%s'", span.Status.Code, codes.Unset)
+		}
+	})
+
+	t.Run("exception", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.New("base error"), "error occurred")
+		span := record(t, err)
+
+		if len(span.Events) != 1 || span.Events[0].Name != "exception" {
+			t.Fatalf("Events: got = '%v', want = '%s'", span.Events, "exception")
+		}
+		attrs := span.Events[0].Attributes
+		if val, _ := attrOf(attrs, "exception.message"); val.AsString() != err.Error() {
+			t.Fatalf("exception.message: got = '%s', want = '%s'", val.AsString(), err.Error())
+		}
+		if val, _ := attrOf(attrs, "exception.type"); !strings.HasSuffix(val.AsString(), "errs.wrapping") {
+			t.Fatalf("exception.type: got = '%s', want suffix = '%s'", val.AsString(), "errs.wrapping")
+		}
+		val, _ := attrOf(attrs, "exception.stacktrace")
+		if !strings.Contains(val.AsString(), "otelerr_test.go") {
+			t.Fatalf("exception.stacktrace: got = '%s', want contains = '%s'", val.AsString(), "otelerr_test.go")
+		}
+		if span.Status.Code != codes.Error || span.Status.Description != err.Error() {
+			t.Fatalf("Status: got = '%v', want = '%s: %s'", span.Status, codes.Error, err.Error())
+		}
+	})
+
+	t.Run("location only", func(t *testing.T) {
+		t.Parallel()
+
+		data, merr := errs.MarshalJSON(errs.New("error occurred"))
+		if merr != nil {
+			t.Fatalf("MarshalJSON(): unexpected error: %v", merr)
+		}
+		err, uerr := errs.UnmarshalJSON(data)
+		if uerr != nil {
+			t.Fatalf("UnmarshalJSON(): unexpected error: %v", uerr)
+		}
+		span := record(t, err)
+
+		val, _ := attrOf(span.Events[0].Attributes, "exception.stacktrace")
+		if !strings.Contains(val.AsString(), "otelerr_test.go") {
+			t.Fatalf("exception.stacktrace: got = '%s', want contains = '%s'", val.AsString(), "otelerr_test.go")
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.New("error occurred",
+			errs.String("string", "value"),
+			errs.Int("int", 1),
+			errs.Float64("float64", 1.5),
+			errs.Bool("bool", true),
+			errs.Duration("duration", time.Second),
+			errs.Secret("token", "secret"),
+		)
+		span := record(t, err)
+
+		tests := []struct {
+			key  string
+			want attribute.Value
+		}{
+			{key: "string", want: attribute.StringValue("value")},
+			{key: "int", want: attribute.IntValue(1)},
+			{key: "float64", want: attribute.Float64Value(1.5)},
+			{key: "bool", want: attribute.BoolValue(true)},
+			{key: "duration", want: attribute.StringValue("1s")},
+			{key: "token", want: attribute.StringValue(errs.Redacted)},
+		}
+		for _, tt := range tests {
+			val, ok := attrOf(span.Attributes, tt.key)
+			if !ok || val != tt.want {
+				t.Fatalf("%s: got = '%v', want = '%v'", tt.key, val.Emit(), tt.want.Emit())
+			}
+		}
+	})
+
+	t.Run("server code", func(t *testing.T) {
+		t.Parallel()
+
+		span := record(t, errs.WithCode(errs.New("error occurred"), errs.Unavailable))
+		if val, _ := attrOf(span.Attributes, "error.type"); val.AsString() != string(errs.Unavailable) {
+			t.Fatalf("error.type: got = '%s', want = '%s'", val.AsString(), errs.Unavailable)
+		}
+		if span.Status.Code != codes.Error {
+			t.Fatalf("Status.Code: got = '%s', want = '%s'", span.Status.Code, codes.Error)
+		}
+	})
+
+	t.Run("client code", func(t *testing.T) {
+		t.Parallel()
+
+		span := record(t, errs.WithCode(errs.New("error occurred"), errs.NotFound))
+		if val, _ := attrOf(span.Attributes, "error.type"); val.AsString() != string(errs.NotFound) {
+			t.Fatalf("error.type: got = '%s', want = '%s'", val.AsString(), errs.NotFound)
+		}
+		if span.Status.Code != codes.Unset {
+			t.Fatalf("Status.Code: got = '%s', want = '%s'", span.Status.Code, codes.Unset)
+		}
+		if len(span.Events) != 1 {
+			t.Fatalf("len(Events): got = %d, want = %d", len(span.Events), 1)
+		}
+	})
+}
@@ -0,0 +1,265 @@
+package errs
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ColorMode defines whether the output of Pretty is colored.
+type ColorMode int
+
+const (
+	// ColorAuto colors the output only when it is written to a terminal and
+	// the NO_COLOR environment variable is not set.
+	ColorAuto ColorMode = iota
+	// ColorAlways always colors the output.
+	ColorAlways
+	// ColorNever never colors the output.
+	ColorNever
+)
+
+// PrettyOptions defines the options of Pretty.
+type PrettyOptions struct {
+	// Color defines whether the output is colored, ColorAuto by default.
+	Color ColorMode
+	// Width is the width at which the messages and the field values are
+	// wrapped. Zero or negative width means no wrapping.
+	Width int
+	// Root is the prefix, like a module path or a directory, relative to
+	// which the files of the locations are shown. If empty, the path of the
+	// main module is used, so that the files of the main module are shown
+	// relative to its root.
+	Root string
+}
+
+// Pretty writes the given error to w in a human readable form, meant for the
+// output of command line tools.
+//
+// The causal graph of the error, as given by Tree, is rendered as an indented
+// tree, with the message of each error followed by its fields, aligned as a
+// key-value table, and its location, with the file shown relative to the
+// root of the module.
+//
+// If the given error is nil, then nothing is written.
+func Pretty(w io.Writer, err error, opts PrettyOptions) error {
+	if err == nil {
+		return nil
+	}
+	p := printer{
+		color: useColor(w, opts.Color),
+		width: opts.Width,
+		root:  opts.Root,
+	}
+	p.node(Tree(err), "", "", true)
+	_, werr := w.Write(p.buf)
+	return werr
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[31m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// minWrapWidth is the minimum width of the wrapped text, below which the text
+// is wrapped at this width regardless of the indentation.
+const minWrapWidth = 20
+
+func useColor(w io.Writer, mode ColorMode) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if len(os.Getenv("NO_COLOR")) != 0 || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+type printer struct {
+	buf   []byte
+	color bool
+	width int
+	root  string
+}
+
+// node writes the given node and its children. The first line of the node is
+// prefixed with head, and the following ones with body.
+func (p *printer) node(n *Node, head string, body string, root bool) {
+	if n == nil {
+		return
+	}
+	// Errors with empty message, like the ones annotating errors not created
+	// by this package, are shown only through their child.
+	if len(n.Children) == 1 && len(nodeMessage(n)) == 0 {
+		p.node(n.Children[0], head, body, root)
+		return
+	}
+
+	gutter := "   "
+	if len(n.Children) != 0 {
+		gutter = "│  "
+	}
+	style := ansiBold
+	if root {
+		style = ansiBold + ansiRed
+	}
+	for idx, line := range p.wrap(nodeMessage(n), body+gutter) {
+		if idx == 0 {
+			p.line(head, p.paint(line, style))
+			continue
+		}
+		p.line(body+gutter, p.paint(line, style))
+	}
+	p.details(n.Err, body+gutter)
+
+	for idx, c := range n.Children {
+		if idx == len(n.Children)-1 {
+			p.node(c, body+"└─ ", body+"   ", false)
+			continue
+		}
+		p.node(c, body+"├─ ", body+"│  ", false)
+	}
+}
+
+// details writes the code, the fields and the location of the given error.
+func (p *printer) details(err error, prefix string) {
+	var keys, vals []string
+	if cerr, ok := err.(CodeError); ok && len(cerr.Code()) != 0 {
+		keys = append(keys, "code")
+		vals = append(vals, string(cerr.Code()))
+	}
+	if ferr, ok := err.(FieldsError); ok {
+		for _, field := range ferr.Fields() {
+			keys = append(keys, field.Key())
+			vals = append(vals, valueString(field))
+		}
+	}
+
+	var keyWidth int
+	for _, key := range keys {
+		keyWidth = max(keyWidth, utf8.RuneCountInString(key))
+	}
+	for idx, key := range keys {
+		pad := strings.Repeat(" ", keyWidth-utf8.RuneCountInString(key)+2)
+		indent := prefix + strings.Repeat(" ", keyWidth+2)
+		for ldx, line := range p.wrap(vals[idx], indent) {
+			if ldx == 0 {
+				p.line(prefix, p.paint(key, ansiCyan)+pad+line)
+				continue
+			}
+			p.line(indent, line)
+		}
+	}
+
+	lerr, ok := err.(LocationError)
+	if !ok {
+		return
+	}
+	fn, file, line := lerr.Location()
+	if len(fn) == 0 && len(file) == 0 {
+		return
+	}
+	loc := Location{Function: fn, File: p.shortFile(file), Line: line}
+	p.line(prefix, p.paint("at "+loc.String(), ansiDim))
+}
+
+// line writes a line with the given prefix.
+func (p *printer) line(prefix string, text string) {
+	p.buf = append(p.buf, p.paint(prefix, ansiDim)...)
+	p.buf = append(p.buf, text...)
+	p.buf = append(p.buf, '\n')
+}
+
+func (p *printer) paint(text string, style string) string {
+	if !p.color || len(text) == 0 || len(strings.TrimSpace(text)) == 0 {
+		return text
+	}
+	return style + text + ansiReset
+}
+
+// wrap splits the given text into lines fitting the width after the given
+// prefix. Words longer than the width are broken.
+func (p *printer) wrap(text string, prefix string) []string {
+	if p.width <= 0 {
+		return strings.Split(text, "\n")
+	}
+	width := max(p.width-utf8.RuneCountInString(prefix), minWrapWidth)
+
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		var line []rune
+		for _, word := range strings.Fields(para) {
+			wrd := []rune(word)
+			if len(line) != 0 && len(line)+1+len(wrd) > width {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			for len(wrd) > width {
+				if len(line) != 0 {
+					lines = append(lines, string(line))
+					line = line[:0]
+				}
+				lines = append(lines, string(wrd[:width]))
+				wrd = wrd[width:]
+			}
+			if len(line) != 0 {
+				line = append(line, ' ')
+			}
+			line = append(line, wrd...)
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// shortFile gives the given file, as trimmed by SetTrimPrefixes, relative to
+// the root of the printer, or of the main module.
+func (p *printer) shortFile(file string) string {
+	root := p.root
+	if len(root) == 0 {
+		root = readBuildInfo().main
+	}
+	if len(root) == 0 {
+		return file
+	}
+	root = strings.TrimSuffix(filepath.ToSlash(root), "/") + "/"
+	if rel := strings.TrimPrefix(filepath.ToSlash(file), root); len(rel) != len(file) {
+		return rel
+	}
+	return file
+}
+
+// nodeMessage gives the message of the error of the given node, without the
+// messages of its children.
+func nodeMessage(n *Node) string {
+	if _, ok := n.Err.(interface{ Unwrap() []error }); ok {
+		return strconv.Itoa(len(n.Children)) + " errors occurred"
+	}
+	msg := message(n.Err)
+	switch n.Err.(type) {
+	case *fundamental, *wrapping, *sentinelError:
+		return msg
+	}
+	if u := errors.Unwrap(n.Err); u != nil {
+		msg = strings.TrimSuffix(msg, ": "+u.Error())
+	}
+	return msg
+}
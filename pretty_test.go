@@ -0,0 +1,132 @@
+package errs_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestPretty(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if err := errs.Pretty(&buf, nil, errs.PrettyOptions{}); err != nil {
+			t.Fatalf("Pretty(): unexpected error: %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("Pretty(): got = '%s', want = ''", buf.String())
+		}
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.Join(errors.New("first error"), fmt.Errorf("second error: %w", errors.New("base error")))
+
+		var buf bytes.Buffer
+		if perr := errs.Pretty(&buf, err, errs.PrettyOptions{}); perr != nil {
+			t.Fatalf("Pretty(): unexpected error: %v", perr)
+		}
+		want := "" +
+			"2 errors occurred\n" +
+			"├─ first error\n" +
+			"└─ second error\n" +
+			"   └─ base error\n"
+		if buf.String() != want {
+			t.Fatalf("Pretty(): got = '%s', want = '%s'", buf.String(), want)
+		}
+	})
+
+	t.Run("details", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.WithCode(errs.New("error occurred", errs.String("key", "value"), errs.Int("longer_key", 1)), errs.NotFound)
+
+		var buf bytes.Buffer
+		if perr := errs.Pretty(&buf, err, errs.PrettyOptions{}); perr != nil {
+			t.Fatalf("Pretty(): unexpected error: %v", perr)
+		}
+		lines := strings.Split(buf.String(), "\n")
+		want := []string{
+			"error occurred",
+			"   code        not_found",
+			"   key         value",
+			"   longer_key  1",
+		}
+		for idx, line := range want {
+			if lines[idx] != line {
+				t.Fatalf("Pretty() line %d: got = '%s', want = '%s'", idx, lines[idx], line)
+			}
+		}
+		if loc := "   at errs_test.TestPretty.func3 (pretty_test.go:"; !strings.HasPrefix(lines[4], loc) {
+			t.Fatalf("Pretty() line 4: got = '%s', want prefix = '%s'", lines[4], loc)
+		}
+	})
+
+	t.Run("root", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if perr := errs.Pretty(&buf, errs.New("error occurred"), errs.PrettyOptions{Root: "github.com/hemantjadon"}); perr != nil {
+			t.Fatalf("Pretty(): unexpected error: %v", perr)
+		}
+		if want := " (errs/pretty_test.go:"; !strings.Contains(buf.String(), want) {
+			t.Fatalf("Pretty(): got = '%s', want contains = '%s'", buf.String(), want)
+		}
+	})
+
+	t.Run("width", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.New("an error with a message too long to fit in a single line")
+
+		var buf bytes.Buffer
+		if perr := errs.Pretty(&buf, err, errs.PrettyOptions{Width: 30}); perr != nil {
+			t.Fatalf("Pretty(): unexpected error: %v", perr)
+		}
+		want := "" +
+			"an error with a message too\n" +
+			"   long to fit in a single\n" +
+			"   line\n"
+		if buf.String() != want {
+			t.Fatalf("Pretty(): got = '%s', want = '%s'", buf.String(), want)
+		}
+	})
+
+	t.Run("color", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.New("error occurred")
+
+		tests := []struct {
+			name  string
+			color errs.ColorMode
+			want  bool
+		}{
+			{name: "auto", color: errs.ColorAuto, want: false},
+			{name: "always", color: errs.ColorAlways, want: true},
+			{name: "never", color: errs.ColorNever, want: false},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var buf bytes.Buffer
+				if perr := errs.Pretty(&buf, err, errs.PrettyOptions{Color: tt.color}); perr != nil {
+					t.Fatalf("Pretty(): unexpected error: %v", perr)
+				}
+				if got := strings.Contains(buf.String(), "\x1b["); got != tt.want {
+					t.Fatalf("colored: got = %t, want = %t", got, tt.want)
+				}
+			})
+		}
+	})
+}
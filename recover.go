@@ -0,0 +1,73 @@
+package errs
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Recover recovers from a panic, converting it into an error which is
+// appended to the error pointed by errp. It must be called directly by a
+// deferred call, as in
+//
+//	defer errs.Recover(&err)
+//
+// The resulting error gives the location and the stack of the panic site
+// rather than of the recovery. The panic value is kept as the field "value" of
+// the error, and panic values which are errors are also wrapped, so that they
+// can still be matched using errors.Is and errors.As.
+func Recover(errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	*errp = Append(*errp, fromPanic(1, r))
+}
+
+// Call calls fn, converting a panic in it into an error as Recover does.
+func Call(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
+
+// Go calls fn in a new goroutine, converting a panic in it into an error as
+// Recover does. The error returned by fn is sent on the returned channel,
+// which is closed afterwards.
+func Go(fn func() error) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		errc <- Call(fn)
+	}()
+	return errc
+}
+
+func fromPanic(skip int, val interface{}) error {
+	stk := panicStack(skip + 1)
+	loc := stk.location()
+	fdm := &fundamental{msg: "panic", fields: []Field{F("value", val)}, loc: loc, stack: stk}
+	if err, ok := val.(error); ok {
+		return newWrapping(fdm, err, true)
+	}
+	return fdm
+}
+
+// panicStack gives the stack of the panic being recovered, starting from the
+// panic site. The frames of the runtime raising the panic, like the ones of
+// nil dereferences and out of range indexes, are skipped. If no panic is found
+// on the stack, then the stack of the caller is given.
+func panicStack(skip int) stack {
+	stk := getStack(skip + 1)
+	for idx, pc := range stk {
+		fn := runtime.FuncForPC(pc - 1)
+		if fn == nil || fn.Name() != "runtime.gopanic" {
+			continue
+		}
+		for idx++; idx < len(stk); idx++ {
+			fn := runtime.FuncForPC(stk[idx] - 1)
+			if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+				return stk[idx:]
+			}
+		}
+	}
+	return stk
+}
@@ -0,0 +1,133 @@
+package errs_test
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("error occurred")
+		err := func() (err error) {
+			defer errs.Recover(&err)
+			return baseErr
+		}()
+		if err != baseErr {
+			t.Fatalf("Recover(): got = '%v', want = '%v'", err, baseErr)
+		}
+	})
+
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+
+		var line int
+		err := func() (err error) {
+			defer errs.Recover(&err)
+			_, _, line, _ = runtime.Caller(0)
+			panic("boom")
+		}()
+		if err == nil {
+			t.Fatalf("Recover(): got = nil, want = non-nil")
+		}
+		if want := "panic (value=boom)"; err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+		if val, ok := errs.LookupAs[string](err, "value"); !ok || val != "boom" {
+			t.Fatalf("LookupAs[string](value): got = '%s, %t', want = '%s, %t'", val, ok, "boom", true)
+		}
+
+		_, file, gotLine := err.(errs.LocationError).Location()
+		if !strings.HasSuffix(file, "recover_test.go") {
+			t.Fatalf("Location() file: got = '%s', want suffix = '%s'", file, "recover_test.go")
+		}
+		if gotLine != line+1 {
+			t.Fatalf("Location() line: got = %d, want = %d", gotLine, line+1)
+		}
+		frames := err.(errs.StackError).StackTrace()
+		if len(frames) == 0 || frames[0].Line != line+1 {
+			t.Fatalf("StackTrace(): got = '%v', want first line = %d", frames, line+1)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("error occurred")
+		err := func() (err error) {
+			defer errs.Recover(&err)
+			panic(baseErr)
+		}()
+		if !errors.Is(err, baseErr) {
+			t.Fatalf("errors.Is(): got = %t, want = %t", false, true)
+		}
+		if want := "panic (value=error occurred): error occurred"; err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+		if val, ok := errs.Lookup(err, "value"); !ok || val != baseErr {
+			t.Fatalf("Lookup(value): got = '%v, %t', want = '%v, %t'", val, ok, baseErr, true)
+		}
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		t.Parallel()
+
+		var line int
+		err := func() (err error) {
+			defer errs.Recover(&err)
+			var m map[string]int
+			_, _, line, _ = runtime.Caller(0)
+			m["key"] = 1
+			return nil
+		}()
+		var rerr runtime.Error
+		if !errors.As(err, &rerr) {
+			t.Fatalf("errors.As(): got = %t, want = %t", false, true)
+		}
+		if _, _, gotLine := err.(errs.LocationError).Location(); gotLine != line+1 {
+			t.Fatalf("Location() line: got = %d, want = %d", gotLine, line+1)
+		}
+	})
+}
+
+func TestCall(t *testing.T) {
+	t.Parallel()
+
+	err := errs.Call(func() error {
+		panic("boom")
+	})
+	if !errs.HasField(err, "value") {
+		t.Fatalf("HasField(value): got = %t, want = %t", false, true)
+	}
+}
+
+func TestGo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("error occurred")
+		if err := <-errs.Go(func() error { return baseErr }); err != baseErr {
+			t.Fatalf("Go(): got = '%v', want = '%v'", err, baseErr)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		err := <-errs.Go(func() error {
+			panic("boom")
+		})
+		if !errs.HasField(err, "value") {
+			t.Fatalf("HasField(value): got = %t, want = %t", false, true)
+		}
+	})
+}
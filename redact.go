@@ -0,0 +1,98 @@
+package errs
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// Redacted is the value reported in place of the value of a redacted field.
+const Redacted = "[REDACTED]"
+
+// RedactionPolicy decides whether the value of a field with the given key and
+// value must be redacted.
+type RedactionPolicy func(key string, val interface{}) bool
+
+var redactionPolicy atomic.Pointer[RedactionPolicy]
+
+// SetRedactionPolicy sets the package wide redaction policy applied to the
+// fields created by this package. The value of a field redacted by the policy
+// is reported as Redacted by Value, and so in the Error string, the JSON
+// encoding and any other rendering of the error. Setting nil policy disables
+// the redaction, except for the fields created by Secret.
+func SetRedactionPolicy(p RedactionPolicy) {
+	if p == nil {
+		redactionPolicy.Store(nil)
+		return
+	}
+	redactionPolicy.Store(&p)
+}
+
+// RedactKeys gives a redaction policy redacting the fields whose keys match
+// any of the given patterns. The patterns are matched case-insensitively with
+// the syntax of path.Match, like "password" or "*token*".
+func RedactKeys(patterns ...string) RedactionPolicy {
+	lower := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		lower = append(lower, strings.ToLower(pattern))
+	}
+	return func(key string, _ interface{}) bool {
+		key = strings.ToLower(key)
+		for _, pattern := range lower {
+			if ok, _ := path.Match(pattern, key); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RedactTypes gives a redaction policy redacting the fields whose values are
+// of the same type as any of the given values.
+func RedactTypes(vals ...interface{}) RedactionPolicy {
+	types := make(map[reflect.Type]struct{}, len(vals))
+	for _, val := range vals {
+		types[reflect.TypeOf(val)] = struct{}{}
+	}
+	return func(_ string, val interface{}) bool {
+		_, ok := types[reflect.TypeOf(val)]
+		return ok
+	}
+}
+
+// RedactEither gives a redaction policy redacting the fields redacted by any
+// of the given policies.
+func RedactEither(policies ...RedactionPolicy) RedactionPolicy {
+	return func(key string, val interface{}) bool {
+		for _, p := range policies {
+			if p(key, val) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Secret creates a new Field with the given key and value, whose value is
+// always redacted regardless of the redaction policy.
+func Secret(key string, val interface{}) Field {
+	return Field{key: key, typ: anyType, val: val, secret: true}
+}
+
+// Reveal gives the raw value of the given field, bypassing any redaction. It
+// must only be used by privileged sinks allowed to access sensitive values.
+func Reveal(f Field) interface{} {
+	return f.raw()
+}
+
+func (f Field) redacted() bool {
+	if f.secret {
+		return true
+	}
+	p := redactionPolicy.Load()
+	if p == nil {
+		return false
+	}
+	return (*p)(f.key, f.raw())
+}
@@ -0,0 +1,109 @@
+package errs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+type token string
+
+func TestSecret(t *testing.T) {
+	t.Parallel()
+
+	err := errs.New("error occurred", errs.Secret("token", "s3cr3t"), errs.String("user", "alice"))
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		want := "error occurred (token=" + errs.Redacted + " user=alice)"
+		if err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		t.Parallel()
+
+		field := err.(errs.FieldsError).Fields()[0]
+		if field.Value() != errs.Redacted {
+			t.Fatalf("Value(): got = '%v', want = '%s'", field.Value(), errs.Redacted)
+		}
+		if errs.Reveal(field) != "s3cr3t" {
+			t.Fatalf("Reveal(): got = '%v', want = '%s'", errs.Reveal(field), "s3cr3t")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		data, jerr := json.Marshal(err)
+		if jerr != nil {
+			t.Fatalf("json.Marshal(): unexpected error: %v", jerr)
+		}
+		if bytes.Contains(data, []byte("s3cr3t")) {
+			t.Fatalf("json.Marshal(): got = '%s', want not contains = '%s'", data, "s3cr3t")
+		}
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		slog.New(slog.NewTextHandler(&buf, nil)).Error("failed", errs.Attr(err))
+		if strings.Contains(buf.String(), "s3cr3t") {
+			t.Fatalf("log: got = '%s', want not contains = '%s'", buf.String(), "s3cr3t")
+		}
+	})
+}
+
+func TestSetRedactionPolicy(t *testing.T) {
+	errs.SetRedactionPolicy(errs.RedactEither(
+		errs.RedactKeys("password", "*_token"),
+		errs.RedactTypes(token("")),
+	))
+	defer errs.SetRedactionPolicy(nil)
+
+	err := errs.New("error occurred",
+		errs.String("Password", "hunter2"),
+		errs.F("access_token", "abc"),
+		errs.F("key", token("xyz")),
+		errs.Int("count", 10),
+	)
+
+	want := "error occurred (Password=" + errs.Redacted + " access_token=" + errs.Redacted + " key=" + errs.Redacted + " count=10)"
+	if err.Error() != want {
+		t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+	}
+
+	fields := err.(errs.FieldsError).Fields()
+	if errs.Reveal(fields[0]) != "hunter2" {
+		t.Fatalf("Reveal(): got = '%v', want = '%s'", errs.Reveal(fields[0]), "hunter2")
+	}
+
+	errs.SetRedactionPolicy(nil)
+	if !strings.Contains(err.Error(), "hunter2") {
+		t.Fatalf("Error(): got = '%s', want contains = '%s'", err.Error(), "hunter2")
+	}
+}
+
+func TestPublic(t *testing.T) {
+	t.Parallel()
+
+	field := errs.String("key", "value")
+	if errs.IsPublic(field) {
+		t.Fatalf("IsPublic(): got = %t, want = %t", true, false)
+	}
+
+	public := errs.Public(field)
+	if !errs.IsPublic(public) {
+		t.Fatalf("IsPublic(Public()): got = %t, want = %t", false, true)
+	}
+	if public.Key() != "key" || public.Value() != "value" {
+		t.Fatalf("Public(): got = '%s=%v', want = '%s=%v'", public.Key(), public.Value(), "key", "value")
+	}
+}
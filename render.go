@@ -0,0 +1,229 @@
+package errs
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Renderer defines how the message and the fields of an error are rendered in
+// its Error string.
+//
+// The Error string of an error wrapping or boxing another error is rendered
+// followed by a colon and the Error string of the other error, unless the
+// renderer is a CauseRenderer.
+type Renderer interface {
+	Render(msg string, fields []Field) string
+}
+
+// CauseRenderer defines a Renderer which also renders the Error string of the
+// error wrapped or boxed by an error, called its cause, so that the Error
+// string as a whole follows the format of the renderer.
+type CauseRenderer interface {
+	Renderer
+	RenderCause(msg string, fields []Field, cause string) string
+}
+
+var renderer atomic.Pointer[Renderer]
+
+// SetRenderer sets the package wide renderer used for the errors which do not
+// have a renderer set using WithRenderer. Setting nil renderer restores the
+// default TextRenderer.
+func SetRenderer(r Renderer) {
+	if r == nil {
+		renderer.Store(nil)
+		return
+	}
+	renderer.Store(&r)
+}
+
+// WithRenderer gives a copy of the given error whose message and fields are
+// rendered with the given renderer. Errors not created by this package are
+// wrapped, so that they can still be unwrapped from the resulting error.
+//
+// If the given error is nil, then nil error is returned.
+func WithRenderer(err error, r Renderer) error {
+	return annotate(err, 1, func(f *fundamental) {
+		f.renderer = r
+	})
+}
+
+func rendererOf(r Renderer) Renderer {
+	if r != nil {
+		return r
+	}
+	if r := renderer.Load(); r != nil {
+		return *r
+	}
+	return TextRenderer{}
+}
+
+// TextRenderer renders the fields as space separated key=value pairs in
+// parentheses after the message, like "msg (k1=v1 k2=v2)". Values are not
+// quoted. It is the default renderer.
+type TextRenderer struct {
+	// TimeLayout is the layout of the time values, time.RFC3339 if empty.
+	TimeLayout string
+}
+
+// Render renders the message and the fields.
+func (r TextRenderer) Render(msg string, fields []Field) string {
+	if len(fields) == 0 {
+		return msg
+	}
+	layout := timeLayout(r.TimeLayout)
+	buf := make([]byte, 0, len(msg)+16*len(fields))
+	buf = append(buf, msg...)
+	buf = append(buf, " ("...)
+	for idx, field := range fields {
+		if idx > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = append(buf, field.Key()...)
+		buf = append(buf, '=')
+		buf = appendValue(buf, field, layout)
+	}
+	buf = append(buf, ')')
+	return string(buf)
+}
+
+// LogfmtRenderer renders the message and the fields in logfmt, like
+// `msg="some message" k1=v1 k2="v 2"`, with the cause of the error, if any,
+// as the last key, like `cause="other message"`. The message and values which
+// are empty or contain spaces, quotes, equal signs or non-printable characters
+// are quoted and escaped, and such characters in keys are replaced with
+// underscores.
+type LogfmtRenderer struct {
+	// TimeLayout is the layout of the time values, time.RFC3339 if empty.
+	TimeLayout string
+}
+
+// Render renders the message and the fields.
+func (r LogfmtRenderer) Render(msg string, fields []Field) string {
+	return string(r.appendLogfmt(nil, msg, fields))
+}
+
+// RenderCause renders the message, the fields and the cause.
+func (r LogfmtRenderer) RenderCause(msg string, fields []Field, cause string) string {
+	buf := r.appendLogfmt(nil, msg, fields)
+	buf = append(buf, " cause="...)
+	start := len(buf)
+	buf = append(buf, cause...)
+	buf = quoteLogfmtValue(buf, start)
+	return string(buf)
+}
+
+func (r LogfmtRenderer) appendLogfmt(buf []byte, msg string, fields []Field) []byte {
+	layout := timeLayout(r.TimeLayout)
+	buf = append(buf, "msg="...)
+	start := len(buf)
+	buf = append(buf, msg...)
+	buf = quoteLogfmtValue(buf, start)
+	for _, field := range fields {
+		buf = append(buf, ' ')
+		buf = appendLogfmtKey(buf, field.Key())
+		buf = append(buf, '=')
+		start := len(buf)
+		buf = appendValue(buf, field, layout)
+		buf = quoteLogfmtValue(buf, start)
+	}
+	return buf
+}
+
+// JSONRenderer renders the fields as a JSON object after the message, like
+// `msg {"k1":"v1","k2":2}`, with the cause of the error, if any, as the last
+// key of the object, like `{"k1":"v1","cause":"other message"}`.
+type JSONRenderer struct {
+	// TimeLayout is the layout of the time values, time.RFC3339 if empty.
+	TimeLayout string
+}
+
+// Render renders the message and the fields.
+func (r JSONRenderer) Render(msg string, fields []Field) string {
+	if len(fields) == 0 {
+		return msg
+	}
+	return string(r.appendJSON(nil, msg, fields, nil))
+}
+
+// RenderCause renders the message, the fields and the cause.
+func (r JSONRenderer) RenderCause(msg string, fields []Field, cause string) string {
+	return string(r.appendJSON(nil, msg, fields, &cause))
+}
+
+func (r JSONRenderer) appendJSON(buf []byte, msg string, fields []Field, cause *string) []byte {
+	layout := timeLayout(r.TimeLayout)
+	buf = append(buf, msg...)
+	buf = append(buf, " {"...)
+	for idx, field := range fields {
+		if idx > 0 {
+			buf = append(buf, ',')
+		}
+		key, _ := marshalJSON(field.Key())
+		buf = append(buf, key...)
+		buf = append(buf, ':')
+		buf = append(buf, valueJSON(field, layout)...)
+	}
+	if cause != nil {
+		if len(fields) > 0 {
+			buf = append(buf, ',')
+		}
+		val, _ := marshalJSON(*cause)
+		buf = append(buf, `"cause":`...)
+		buf = append(buf, val...)
+	}
+	buf = append(buf, '}')
+	return buf
+}
+
+// NoFieldsRenderer renders only the message, leaving out the fields.
+type NoFieldsRenderer struct{}
+
+// Render renders the message.
+func (NoFieldsRenderer) Render(msg string, _ []Field) string {
+	return msg
+}
+
+func timeLayout(layout string) string {
+	if len(layout) == 0 {
+		return time.RFC3339
+	}
+	return layout
+}
+
+func appendLogfmtKey(buf []byte, key string) []byte {
+	if len(key) == 0 {
+		return append(buf, '_')
+	}
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			r = '_'
+		}
+		buf = utf8.AppendRune(buf, r)
+	}
+	return buf
+}
+
+// quoteLogfmtValue quotes and escapes the value at the end of the buffer,
+// starting at the given index, if it needs quoting.
+func quoteLogfmtValue(buf []byte, start int) []byte {
+	if !needsQuoting(string(buf[start:])) {
+		return buf
+	}
+	val := string(buf[start:])
+	return strconv.AppendQuote(buf[:start], val)
+}
+
+func needsQuoting(val string) bool {
+	if len(val) == 0 {
+		return true
+	}
+	for _, r := range val {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
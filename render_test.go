@@ -0,0 +1,135 @@
+package errs_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestRenderer(t *testing.T) {
+	t.Parallel()
+
+	tt := time.Date(2021, 6, 15, 10, 30, 0, 0, time.UTC)
+	fields := []errs.Field{
+		errs.String("state", "heating up"),
+		errs.Int("temperature", 10),
+		errs.Time("at", tt),
+		errs.String("quote", `say "hi"`),
+		errs.String("empty", ""),
+	}
+
+	tests := []struct {
+		name     string
+		renderer errs.Renderer
+		want     string
+		bare     string
+	}{
+		{
+			name:     "TextRenderer",
+			renderer: errs.TextRenderer{},
+			want:     `error occurred (state=heating up temperature=10 at=2021-06-15T10:30:00Z quote=say "hi" empty=)`,
+		},
+		{
+			name:     "LogfmtRenderer",
+			renderer: errs.LogfmtRenderer{TimeLayout: time.DateOnly},
+			want:     `msg="error occurred" state="heating up" temperature=10 at=2021-06-15 quote="say \"hi\"" empty=""`,
+			bare:     `msg="error occurred"`,
+		},
+		{
+			name:     "JSONRenderer",
+			renderer: errs.JSONRenderer{},
+			want:     `error occurred {"state":"heating up","temperature":10,"at":"2021-06-15T10:30:00Z","quote":"say \"hi\"","empty":""}`,
+		},
+		{
+			name:     "NoFieldsRenderer",
+			renderer: errs.NoFieldsRenderer{},
+			want:     `error occurred`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.renderer.Render("error occurred", fields)
+			if got != tt.want {
+				t.Fatalf("Render(): got = '%s', want = '%s'", got, tt.want)
+			}
+			bare := tt.bare
+			if len(bare) == 0 {
+				bare = "error occurred"
+			}
+			if got := tt.renderer.Render("error occurred", nil); got != bare {
+				t.Fatalf("Render(nil): got = '%s', want = '%s'", got, bare)
+			}
+		})
+	}
+}
+
+func TestWithRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		if err := errs.WithRenderer(nil, errs.NoFieldsRenderer{}); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("base error", errs.String("field1", "value 1"))
+		err := errs.WithRenderer(errs.Wrap(baseErr, "error occurred", errs.Int("field2", 2)), errs.LogfmtRenderer{})
+
+		want := `msg="error occurred" field2=2 cause="base error (field1=value 1)"`
+		if err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+		if !errors.Is(err, baseErr) {
+			t.Fatalf("should wrap base error")
+		}
+	})
+
+	t.Run("wrapping LogfmtRenderer", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.WithRenderer(errs.New("inner failed", errs.String("k", "a b")), errs.LogfmtRenderer{})
+		err := errs.WithRenderer(errs.Wrap(baseErr, "outer failed", errs.Int("n", 1)), errs.LogfmtRenderer{})
+
+		want := `msg="outer failed" n=1 cause="msg=\"inner failed\" k=\"a b\""`
+		if err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+	})
+
+	t.Run("wrapping JSONRenderer", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("inner failed")
+		err := errs.WithRenderer(errs.Box(baseErr, "outer failed", errs.String("q", "a<b&c")), errs.JSONRenderer{})
+
+		want := `outer failed {"q":"a<b&c","cause":"inner failed"}`
+		if err.Error() != want {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), want)
+		}
+	})
+}
+
+func TestSetRenderer(t *testing.T) {
+	errs.SetRenderer(errs.NoFieldsRenderer{})
+	defer errs.SetRenderer(nil)
+
+	err := errs.New("error occurred", errs.Int("field1", 1))
+	if err.Error() != "error occurred" {
+		t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), "error occurred")
+	}
+
+	err = errs.WithRenderer(err, errs.TextRenderer{})
+	if err.Error() != "error occurred (field1=1)" {
+		t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), "error occurred (field1=1)")
+	}
+}
@@ -0,0 +1,99 @@
+// Package retry provides retrying of operations failing with retryable errors,
+// as classified by errs.IsRetryable, with backoff policies.
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hemantjadon/errs"
+)
+
+// Policy defines a backoff policy deciding the delays between the attempts of
+// an operation.
+type Policy interface {
+	// Backoff gives the delay before the given retry, starting from 1, and
+	// whether the retry must be made at all.
+	Backoff(retry int) (time.Duration, bool)
+}
+
+// PolicyFunc is an adapter to allow the use of ordinary functions as Policy.
+type PolicyFunc func(retry int) (time.Duration, bool)
+
+// Backoff calls f(retry).
+func (f PolicyFunc) Backoff(retry int) (time.Duration, bool) {
+	return f(retry)
+}
+
+// Constant gives a policy making at most the given number of retries, with the
+// given delay before each.
+func Constant(delay time.Duration, retries int) Policy {
+	return PolicyFunc(func(retry int) (time.Duration, bool) {
+		if retry > retries {
+			return 0, false
+		}
+		return delay, true
+	})
+}
+
+// Exponential gives a policy making at most the given number of retries, with
+// the delay doubling before each retry starting from base, capped at max.
+func Exponential(base, max time.Duration, retries int) Policy {
+	return PolicyFunc(func(retry int) (time.Duration, bool) {
+		if retry > retries {
+			return 0, false
+		}
+		delay := float64(base) * math.Pow(2, float64(retry-1))
+		if delay > float64(max) {
+			return max, true
+		}
+		return time.Duration(delay), true
+	})
+}
+
+// Jitter gives a policy randomizing the delays of the given policy, by
+// reducing each by up to the given fraction of it.
+func Jitter(p Policy, fraction float64) Policy {
+	return PolicyFunc(func(retry int) (time.Duration, bool) {
+		delay, ok := p.Backoff(retry)
+		if !ok {
+			return 0, false
+		}
+		return delay - time.Duration(rand.Float64()*fraction*float64(delay)), true
+	})
+}
+
+// Do calls fn, retrying it with the backoff of the given policy as long as it
+// fails with a retryable error. The delay before a retry is extended to the
+// one given by errs.RetryAfter of the error, if that is longer.
+//
+// Errors which are not retryable are returned as is. When the policy does not
+// allow any more retries, or the context is done while waiting for a retry,
+// the last error is returned wrapped with the number of attempts made.
+func Do(ctx context.Context, p Policy, fn func(ctx context.Context) error) error {
+	for retry := 1; ; retry++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if !errs.IsRetryable(err) {
+			return err
+		}
+		delay, ok := p.Backoff(retry)
+		if !ok {
+			return errs.Wrap(err, "retries exhausted", errs.Int("attempts", retry))
+		}
+		if after := errs.RetryAfter(err); after > delay {
+			delay = after
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errs.Wrap(err, "retry aborted", errs.Int("attempts", retry), errs.F("cause", context.Cause(ctx)))
+		case <-timer.C:
+		}
+	}
+}
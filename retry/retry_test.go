@@ -0,0 +1,140 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hemantjadon/errs"
+	"github.com/hemantjadon/errs/retry"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Constant", func(t *testing.T) {
+		t.Parallel()
+
+		p := retry.Constant(time.Second, 2)
+		for r, want := range []time.Duration{time.Second, time.Second} {
+			delay, ok := p.Backoff(r + 1)
+			if !ok || delay != want {
+				t.Fatalf("Backoff(%d): got = '%s, %t', want = '%s, %t'", r+1, delay, ok, want, true)
+			}
+		}
+		if _, ok := p.Backoff(3); ok {
+			t.Fatalf("Backoff(3): got = %t, want = %t", ok, false)
+		}
+	})
+
+	t.Run("Exponential", func(t *testing.T) {
+		t.Parallel()
+
+		p := retry.Exponential(time.Second, 5*time.Second, 4)
+		for r, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+			delay, ok := p.Backoff(r + 1)
+			if !ok || delay != want {
+				t.Fatalf("Backoff(%d): got = '%s, %t', want = '%s, %t'", r+1, delay, ok, want, true)
+			}
+		}
+		if _, ok := p.Backoff(5); ok {
+			t.Fatalf("Backoff(5): got = %t, want = %t", ok, false)
+		}
+	})
+
+	t.Run("Jitter", func(t *testing.T) {
+		t.Parallel()
+
+		p := retry.Jitter(retry.Constant(time.Second, 1), 0.5)
+		delay, ok := p.Backoff(1)
+		if !ok || delay < time.Second/2 || delay > time.Second {
+			t.Fatalf("Backoff(1): got = '%s, %t', want = '%s, %t'", delay, ok, "[500ms, 1s]", true)
+		}
+		if _, ok := p.Backoff(2); ok {
+			t.Fatalf("Backoff(2): got = %t, want = %t", ok, false)
+		}
+	})
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	p := retry.Constant(time.Millisecond, 3)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var attempts int
+		err := retry.Do(context.Background(), p, func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errs.Retryable(errs.New("error occurred"), 0)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Do(): unexpected error: %v", err)
+		}
+		if attempts != 3 {
+			t.Fatalf("attempts: got = %d, want = %d", attempts, 3)
+		}
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.New("error occurred")
+
+		var attempts int
+		err := retry.Do(context.Background(), p, func(context.Context) error {
+			attempts++
+			return baseErr
+		})
+		if err != baseErr {
+			t.Fatalf("Do(): got = '%v', want = '%v'", err, baseErr)
+		}
+		if attempts != 1 {
+			t.Fatalf("attempts: got = %d, want = %d", attempts, 1)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.Retryable(errs.New("error occurred"), 0)
+
+		var attempts int
+		err := retry.Do(context.Background(), p, func(context.Context) error {
+			attempts++
+			return baseErr
+		})
+		if !errors.Is(err, baseErr) {
+			t.Fatalf("Do(): got = '%v', want wraps = '%v'", err, baseErr)
+		}
+		if attempts != 4 {
+			t.Fatalf("attempts: got = %d, want = %d", attempts, 4)
+		}
+		if n, ok := errs.LookupAs[int](err, "attempts"); !ok || n != 4 {
+			t.Fatalf("LookupAs[int](attempts): got = '%d, %t', want = '%d, %t'", n, ok, 4, true)
+		}
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		baseErr := errs.Retryable(errs.New("error occurred"), time.Hour)
+
+		err := retry.Do(ctx, p, func(context.Context) error {
+			cancel()
+			return baseErr
+		})
+		if !errors.Is(err, baseErr) {
+			t.Fatalf("Do(): got = '%v', want wraps = '%v'", err, baseErr)
+		}
+		if !errs.HasField(err, "cause") {
+			t.Fatalf("HasField(cause): got = %t, want = %t", false, true)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package errs
+
+import "time"
+
+// Retryable gives a copy of the given error marked as retryable after the
+// given delay. Errors not created by this package are wrapped, so that they
+// can still be unwrapped from the resulting error.
+//
+// If the given error is nil, then nil error is returned.
+func Retryable(err error, after time.Duration) error {
+	return annotate(err, 1, func(f *fundamental) {
+		f.retry = true
+		f.after = after
+	})
+}
+
+// IsRetryable reports whether the operation failing with the given error can
+// be retried. The errors resulting to the error are searched in the order of
+// Walk, for errors marked retryable using Retryable, and errors reporting
+// themselves as temporary or timed out, like the ones of package net, through
+// Temporary() bool or Timeout() bool methods.
+func IsRetryable(err error) bool {
+	var retryable bool
+	Walk(err, func(n *Node) bool {
+		switch e := n.Err.(type) {
+		case RetryableError:
+			retryable = e.Retryable()
+		case interface{ Temporary() bool }:
+			retryable = e.Temporary()
+		}
+		if terr, ok := n.Err.(interface{ Timeout() bool }); ok && !retryable {
+			retryable = terr.Timeout()
+		}
+		return !retryable
+	})
+	return retryable
+}
+
+// RetryAfter gives the delay after which the operation failing with the given
+// error should be retried, as set by the outermost error marked retryable
+// using Retryable. If no error is marked retryable, then zero is returned.
+func RetryAfter(err error) time.Duration {
+	var after time.Duration
+	Walk(err, func(n *Node) bool {
+		rerr, ok := n.Err.(RetryableError)
+		if !ok || !rerr.Retryable() {
+			return true
+		}
+		after = rerr.RetryAfter()
+		return false
+	})
+	return after
+}
@@ -0,0 +1,83 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestRetryable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		if err := errs.Retryable(nil, time.Second); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+		if errs.IsRetryable(nil) {
+			t.Fatalf("IsRetryable(): got = %t, want = %t", true, false)
+		}
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errors.New("base error"), "error occurred")
+		if errs.IsRetryable(err) {
+			t.Fatalf("IsRetryable(): got = %t, want = %t", true, false)
+		}
+		if after := errs.RetryAfter(err); after != 0 {
+			t.Fatalf("RetryAfter(): got = '%s', want = '%s'", after, time.Duration(0))
+		}
+	})
+
+	t.Run("retryable", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := errs.Box(errs.Retryable(baseErr, time.Second), "error occurred")
+
+		if err.Error() != "error occurred: base error" {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), "error occurred: base error")
+		}
+		if !errs.IsRetryable(err) {
+			t.Fatalf("IsRetryable(): got = %t, want = %t", false, true)
+		}
+		if after := errs.RetryAfter(err); after != time.Second {
+			t.Fatalf("RetryAfter(): got = '%s', want = '%s'", after, time.Second)
+		}
+	})
+
+	t.Run("outermost delay", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errs.Retryable(errs.New("base error"), time.Second)
+		err := errs.Retryable(errs.Wrap(baseErr, "error occurred"), time.Minute)
+
+		if after := errs.RetryAfter(err); after != time.Minute {
+			t.Fatalf("RetryAfter(): got = '%s', want = '%s'", after, time.Minute)
+		}
+	})
+
+	t.Run("net errors", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := fmt.Errorf("lookup: %w", &net.DNSError{Err: "timed out", IsTimeout: true})
+		if !errs.IsRetryable(errs.Wrap(timeout, "error occurred")) {
+			t.Fatalf("IsRetryable(timeout): got = %t, want = %t", false, true)
+		}
+		temporary := &net.DNSError{Err: "temporary", IsTemporary: true}
+		if !errs.IsRetryable(errs.Append(errs.New("error occurred"), temporary)) {
+			t.Fatalf("IsRetryable(temporary): got = %t, want = %t", false, true)
+		}
+		notFound := &net.DNSError{Err: "no such host", IsNotFound: true}
+		if errs.IsRetryable(notFound) {
+			t.Fatalf("IsRetryable(notFound): got = %t, want = %t", true, false)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package errs
+
+// Sentinel defines an error to be declared once and matched using errors.Is.
+//
+//	var ErrNotFound = errs.NewSentinel("not found")
+//
+// Sentinels are matched by identity rather than by message, so sentinels
+// created separately never match each other, even with the same message.
+type Sentinel struct {
+	msg string
+}
+
+// NewSentinel creates a new Sentinel with the given message.
+func NewSentinel(message string) *Sentinel {
+	return &Sentinel{msg: message}
+}
+
+func (s *Sentinel) Error() string {
+	return s.msg
+}
+
+// With creates a new error with the message of the sentinel and the given
+// fields, located at the place With is called. The error matches the sentinel
+// using errors.Is.
+func (s *Sentinel) With(fields ...Field) error {
+	fdm := newFundamental(1, s.msg, fields)
+	serr := sentinelError{fundamental: fdm, sentinel: s}
+	return &serr
+}
+
+type sentinelError struct {
+	*fundamental
+	sentinel *Sentinel
+}
+
+// Is reports whether the error is an instance of the given sentinel, or a copy
+// of the given error.
+func (s sentinelError) Is(target error) bool {
+	if t, ok := target.(*Sentinel); ok {
+		return t == s.sentinel
+	}
+	return s.fundamental.Is(target)
+}
@@ -0,0 +1,63 @@
+package errs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+var errNotFound = errs.NewSentinel("not found")
+
+func TestSentinel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		if errNotFound.Error() != "not found" {
+			t.Fatalf("Error(): got = '%s', want = '%s'", errNotFound.Error(), "not found")
+		}
+		if !errors.Is(errs.Wrap(errNotFound, "error occurred"), errNotFound) {
+			t.Fatalf("should wrap sentinel")
+		}
+	})
+
+	t.Run("With", func(t *testing.T) {
+		t.Parallel()
+
+		err := errNotFound.With(errs.String("id", "10"))
+
+		if err.Error() != "not found (id=10)" {
+			t.Fatalf("Error(): got = '%s', want = '%s'", err.Error(), "not found (id=10)")
+		}
+		if !errors.Is(err, errNotFound) {
+			t.Fatalf("should match sentinel")
+		}
+		if errors.Is(err, errs.NewSentinel("other")) {
+			t.Fatalf("should not match other sentinel")
+		}
+		if errors.Is(err, errs.NewSentinel("not found")) {
+			t.Fatalf("should not match other sentinel with same message")
+		}
+		if len(err.(errs.FieldsError).Fields()) != 1 {
+			t.Fatalf("len(Fields()): got = %d, want = %d", len(err.(errs.FieldsError).Fields()), 1)
+		}
+		if _, _, line := err.(errs.LocationError).Location(); line == 0 {
+			t.Fatalf("got line = '%d', want = '%s'", line, "non-zero")
+		}
+	})
+
+	t.Run("WithCode", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.WithCode(errNotFound.With(), errs.NotFound), "error occurred")
+
+		if !errors.Is(err, errNotFound) {
+			t.Fatalf("should match sentinel")
+		}
+		if code := errs.CodeOf(err); code != errs.NotFound {
+			t.Fatalf("CodeOf(): got = '%s', want = '%s'", code, errs.NotFound)
+		}
+	})
+}
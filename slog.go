@@ -0,0 +1,134 @@
+package errs
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Attr gives a slog.Attr with key "error" for the given error, whose value is
+// a group of the message, fields, location and chain of the error.
+//
+// If the given error is nil, then an empty slog.Attr is returned, which is
+// ignored by the slog handlers.
+func Attr(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	return slog.Attr{Key: "error", Value: logValue(err)}
+}
+
+// FieldToAttr converts the given Field to a slog.Attr.
+func FieldToAttr(fld Field) slog.Attr {
+	if fld.redacted() {
+		return slog.String(fld.key, Redacted)
+	}
+	switch fld.typ {
+	case stringType:
+		return slog.String(fld.key, fld.str)
+	case intType, int64Type:
+		return slog.Int64(fld.key, fld.num)
+	case float64Type:
+		return slog.Float64(fld.key, math.Float64frombits(uint64(fld.num)))
+	case boolType:
+		return slog.Bool(fld.key, fld.num == 1)
+	case durationType:
+		return slog.Duration(fld.key, time.Duration(fld.num))
+	case stringerType:
+		return slog.String(fld.key, valueString(fld))
+	default:
+		return slog.Any(fld.key, fld.Value())
+	}
+}
+
+// AttrToField converts the given slog.Attr to a Field. The value of the
+// attribute is resolved before the conversion.
+func AttrToField(a slog.Attr) Field {
+	return F(a.Key, a.Value.Resolve().Any())
+}
+
+// LogValue gives the slog.Value of the error, which is a group of its
+// message, code, fields and location.
+func (f fundamental) LogValue() slog.Value {
+	return logValue(&f)
+}
+
+// LogValue gives the slog.Value of the error, which is a group of its
+// message, code, fields, location and chain.
+func (w wrapping) LogValue() slog.Value {
+	return logValue(&w)
+}
+
+// LogValue gives the slog.Value of the error, which is a group of its message
+// and each of the aggregated errors.
+func (m multi) LogValue() slog.Value {
+	return logValue(&m)
+}
+
+func logValue(err error) slog.Value {
+	attrs := layerAttrs(err, "")
+	switch e := err.(type) {
+	case MultiError:
+		errs := e.Errors()
+		eattrs := make([]slog.Attr, 0, len(errs))
+		for idx, c := range errs {
+			eattrs = append(eattrs, slog.Attr{Key: strconv.Itoa(idx), Value: logValue(c)})
+		}
+		attrs = append(attrs, slog.Attr{Key: "errors", Value: slog.GroupValue(eattrs...)})
+	case ChainError:
+		chain := e.Chain()
+		cattrs := make([]slog.Attr, 0, len(chain))
+		var code Code
+		for _, c := range chain {
+			// Empty layers are left out, with their code carried to the next
+			// error.
+			if isEmptyLayer(c) {
+				if len(code) == 0 {
+					code = c.(*fundamental).code
+				}
+				continue
+			}
+			cattrs = append(cattrs, slog.Attr{Key: strconv.Itoa(len(cattrs)), Value: slog.GroupValue(layerAttrs(c, code)...)})
+			code = ""
+		}
+		if w, ok := err.(*wrapping); ok && isEmptyLayer(w.fundamental) && len(cattrs) != 0 {
+			attrs = append([]slog.Attr{}, cattrs[0].Value.Group()...)
+		}
+		attrs = append(attrs, slog.Attr{Key: "chain", Value: slog.GroupValue(cattrs...)})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// layerAttrs gives the attributes of the given error without its chain. The
+// given code is used when the error does not have a code.
+func layerAttrs(err error, code Code) []slog.Attr {
+	attrs := []slog.Attr{slog.String("msg", message(err))}
+	if cerr, ok := err.(CodeError); ok && len(cerr.Code()) != 0 {
+		code = cerr.Code()
+	}
+	if len(code) != 0 {
+		attrs = append(attrs, slog.String("code", string(code)))
+	}
+	if ferr, ok := err.(FieldsError); ok {
+		fields := ferr.Fields()
+		if len(fields) != 0 {
+			fattrs := make([]slog.Attr, 0, len(fields))
+			for _, field := range fields {
+				fattrs = append(fattrs, FieldToAttr(field))
+			}
+			attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fattrs...)})
+		}
+	}
+	if lerr, ok := err.(LocationError); ok {
+		fn, file, line := lerr.Location()
+		if len(fn) != 0 || len(file) != 0 {
+			attrs = append(attrs, slog.Group("location",
+				slog.String("function", fn),
+				slog.String("file", file),
+				slog.Int("line", line),
+			))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,161 @@
+package errs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestLogValue(t *testing.T) {
+	t.Parallel()
+
+	log := func(t *testing.T, attr slog.Attr) map[string]interface{} {
+		t.Helper()
+
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		logger.Error("failed", attr)
+
+		var record map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatalf("json.Unmarshal(): unexpected error: %v", err)
+		}
+		return record
+	}
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		record := log(t, errs.Attr(nil))
+		if _, ok := record["error"]; ok {
+			t.Fatalf("record[error]: got = '%v', want = '%s'", record["error"], "absent")
+		}
+	})
+
+	t.Run("fundamental", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.New("error occurred", errs.F("field1", "value1"), errs.F("field2", 10))
+
+		record := log(t, slog.Any("err", err))
+		group, ok := record["err"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("record[err]: got type = '%T', want = 'group'", record["err"])
+		}
+		if group["msg"] != "error occurred" {
+			t.Fatalf("msg: got = '%v', want = '%s'", group["msg"], "error occurred")
+		}
+		fields, ok := group["fields"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("fields: got type = '%T', want = 'group'", group["fields"])
+		}
+		if fields["field1"] != "value1" {
+			t.Fatalf("fields[field1]: got = '%v', want = '%s'", fields["field1"], "value1")
+		}
+		if fields["field2"] != float64(10) {
+			t.Fatalf("fields[field2]: got = '%v', want = '%d'", fields["field2"], 10)
+		}
+		if _, ok := group["location"].(map[string]interface{}); !ok {
+			t.Fatalf("location: got type = '%T', want = 'group'", group["location"])
+		}
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := errs.Wrap(errs.Box(baseErr, "inner error"), "error occurred")
+
+		record := log(t, errs.Attr(err))
+		group, ok := record["error"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("record[error]: got type = '%T', want = 'group'", record["error"])
+		}
+		chain, ok := group["chain"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("chain: got type = '%T', want = 'group'", group["chain"])
+		}
+		if len(chain) != 3 {
+			t.Fatalf("len(chain): got = %d, want = %d", len(chain), 3)
+		}
+		last, ok := chain["2"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("chain[2]: got type = '%T', want = 'group'", chain["2"])
+		}
+		if last["msg"] != baseErr.Error() {
+			t.Fatalf("chain[2].msg: got = '%v', want = '%s'", last["msg"], baseErr.Error())
+		}
+	})
+	t.Run("empty layer", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := errs.WithCode(baseErr, errs.NotFound)
+
+		record := log(t, errs.Attr(err))
+		group, ok := record["error"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("record[error]: got type = '%T', want = 'group'", record["error"])
+		}
+		if group["msg"] != baseErr.Error() {
+			t.Fatalf("msg: got = '%v', want = '%s'", group["msg"], baseErr.Error())
+		}
+		if group["code"] != string(errs.NotFound) {
+			t.Fatalf("code: got = '%v', want = '%s'", group["code"], errs.NotFound)
+		}
+		chain, ok := group["chain"].(map[string]interface{})
+		if !ok || len(chain) != 1 {
+			t.Fatalf("chain: got = '%v', want = '%s'", group["chain"], "1 layer")
+		}
+		first, _ := chain["0"].(map[string]interface{})
+		if first["msg"] != baseErr.Error() || first["code"] != string(errs.NotFound) {
+			t.Fatalf("chain[0]: got = '%v', want = '%s'", first, "base error with code")
+		}
+	})
+}
+
+func TestFieldAttr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("FieldToAttr", func(t *testing.T) {
+		t.Parallel()
+
+		tt := time.Now()
+		attr := errs.FieldToAttr(errs.F("field1", tt))
+		if attr.Key != "field1" {
+			t.Fatalf("Key: got = '%s', want = '%s'", attr.Key, "field1")
+		}
+		if attr.Value.Kind() != slog.KindTime {
+			t.Fatalf("Value.Kind(): got = '%s', want = '%s'", attr.Value.Kind(), slog.KindTime)
+		}
+		if !attr.Value.Time().Equal(tt) {
+			t.Fatalf("Value.Time(): got = '%v', want = '%v'", attr.Value.Time(), tt)
+		}
+	})
+
+	t.Run("FieldToAttr Stringer", func(t *testing.T) {
+		t.Parallel()
+
+		attr := errs.FieldToAttr(errs.Stringer("field1", stringer{}))
+		if attr.Value.Kind() != slog.KindString || attr.Value.String() != "stringer" {
+			t.Fatalf("Value: got = '%s %v', want = '%s %s'", attr.Value.Kind(), attr.Value, slog.KindString, "stringer")
+		}
+	})
+
+	t.Run("AttrToField", func(t *testing.T) {
+		t.Parallel()
+
+		field := errs.AttrToField(slog.Int("field1", 10))
+		if field.Key() != "field1" {
+			t.Fatalf("Key(): got = '%s', want = '%s'", field.Key(), "field1")
+		}
+		if field.Value() != int64(10) {
+			t.Fatalf("Value(): got = '%v', want = '%v'", field.Value(), int64(10))
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package errs
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// maxStackDepth is the maximum number of frames captured for an error.
+const maxStackDepth = 32
+
+// Frame defines a single frame of a stack trace. The file of the frame is
+// trimmed as set by SetTrimPrefixes.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Helper marks the calling function as a helper function creating errors on
+// behalf of its callers, like testing.T.Helper. The frames of the helper
+// functions are skipped when the location and the stack of an error are
+// captured, so that they give the caller of the helper instead.
+//
+// Helper can be called from multiple goroutines simultaneously.
+func Helper() {
+	var pc [1]uintptr
+	if runtime.Callers(2, pc[:]) == 0 {
+		return
+	}
+	frame, _ := runtime.CallersFrames(pc[:]).Next()
+	if _, ok := helpers.Load(frame.Function); ok {
+		return
+	}
+	helpers.Store(frame.Function, struct{}{})
+	hasHelpers.Store(true)
+}
+
+var (
+	// helpers holds the names of the functions marked using Helper.
+	helpers    sync.Map
+	hasHelpers atomic.Bool
+)
+
+func isHelper(fn string) bool {
+	if !hasHelpers.Load() {
+		return false
+	}
+	_, ok := helpers.Load(fn)
+	return ok
+}
+
+// stack holds the program counters of a call stack, which are resolved into
+// frames only on demand.
+type stack []uintptr
+
+// location gives the location of the first frame of the stack which is not of
+// a helper function, with the file kept untrimmed.
+func (s stack) location() Location {
+	if len(s) == 0 {
+		return Location{}
+	}
+	cfs := runtime.CallersFrames(s)
+	for {
+		cf, more := cfs.Next()
+		if !isHelper(cf.Function) {
+			return Location{Function: cf.Function, File: cf.File, Line: cf.Line}
+		}
+		if !more {
+			return Location{}
+		}
+	}
+}
+
+func (s stack) frames() []Frame {
+	if len(s) == 0 {
+		return nil
+	}
+	frames := make([]Frame, 0, len(s))
+	cfs := runtime.CallersFrames(s)
+	for {
+		cf, more := cfs.Next()
+		// Leading frames of helper functions are skipped, as they are for the
+		// location.
+		if len(frames) != 0 || !isHelper(cf.Function) {
+			frames = append(frames, Frame{
+				Function: cf.Function,
+				File:     trimFile(cf.Function, cf.File),
+				Line:     cf.Line,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+func getStack(skip int) stack {
+	// The stack is captured into an array which does not escape, so that only
+	// the captured frames are allocated.
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	stk := make(stack, n)
+	copy(stk, pcs[:n])
+	return stk
+}
@@ -0,0 +1,182 @@
+package errs_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestStackTrace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("New", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.New("error occurred")
+
+		serr, ok := err.(errs.StackError)
+		if !ok {
+			t.Fatalf("got type = '%T', want = 'StackError'", err)
+		}
+
+		frames := serr.StackTrace()
+		if len(frames) == 0 {
+			t.Fatalf("len(frames): got = %d, want = '%s'", len(frames), "non-zero")
+		}
+
+		fn, file, line := err.(errs.LocationError).Location()
+		if frames[0].Function != fn {
+			t.Fatalf("frames[0].Function: got = '%s', want = '%s'", frames[0].Function, fn)
+		}
+		if frames[0].File != file {
+			t.Fatalf("frames[0].File: got = '%s', want = '%s'", frames[0].File, file)
+		}
+		if frames[0].Line != line {
+			t.Fatalf("frames[0].Line: got = %d, want = %d", frames[0].Line, line)
+		}
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Wrap(errs.New("base error"), "error occurred")
+
+		serr, ok := err.(errs.StackError)
+		if !ok {
+			t.Fatalf("got type = '%T', want = 'StackError'", err)
+		}
+
+		frames := serr.StackTrace()
+		if len(frames) == 0 {
+			t.Fatalf("len(frames): got = %d, want = '%s'", len(frames), "non-zero")
+		}
+
+		fn, _, line := err.(errs.LocationError).Location()
+		if frames[0].Function != fn {
+			t.Fatalf("frames[0].Function: got = '%s', want = '%s'", frames[0].Function, fn)
+		}
+		if frames[0].Line != line {
+			t.Fatalf("frames[0].Line: got = %d, want = %d", frames[0].Line, line)
+		}
+	})
+
+	t.Run("Box", func(t *testing.T) {
+		t.Parallel()
+
+		err := errs.Box(errs.New("base error"), "error occurred")
+
+		serr, ok := err.(errs.StackError)
+		if !ok {
+			t.Fatalf("got type = '%T', want = 'StackError'", err)
+		}
+
+		frames := serr.StackTrace()
+		if len(frames) < 2 {
+			t.Fatalf("len(frames): got = %d, want = '%s'", len(frames), "at least 2")
+		}
+		if frames[1].Function != "testing.tRunner" {
+			t.Fatalf("frames[1].Function: got = '%s', want = '%s'", frames[1].Function, "testing.tRunner")
+		}
+	})
+}
+
+// notFound is a helper creating errors on behalf of its callers.
+func notFound(key string) error {
+	errs.Helper()
+	return errs.New("not found", errs.String("key", key))
+}
+
+// wrapNotFound is a helper calling another helper.
+func wrapNotFound(key string) error {
+	errs.Helper()
+	return errs.Wrap(notFound(key), "lookup failed")
+}
+
+// newDepth, wrapDepth and boxDepth create errors with the location of their
+// caller without being marked as helpers.
+func newDepth() error {
+	return errs.NewDepth(1, "error occurred")
+}
+
+func wrapDepth() error {
+	return errs.WrapDepth(1, errs.New("base error"), "error occurred")
+}
+
+func boxDepth() error {
+	return errs.BoxDepth(1, errs.New("base error"), "error occurred")
+}
+
+func TestDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{name: "NewDepth", fn: newDepth},
+		{name: "WrapDepth", fn: wrapDepth},
+		{name: "BoxDepth", fn: boxDepth},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, line, _ := runtime.Caller(0)
+			err := tt.fn()
+
+			if _, _, got := err.(errs.LocationError).Location(); got != line+1 {
+				t.Fatalf("Location() line: got = %d, want = %d", got, line+1)
+			}
+			if frames := err.(errs.StackError).StackTrace(); frames[0].Line != line+1 {
+				t.Fatalf("frames[0].Line: got = %d, want = %d", frames[0].Line, line+1)
+			}
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		if err := errs.NewDepth(0, ""); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+		if err := errs.WrapDepth(0, nil, "error occurred"); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+		if err := errs.BoxDepth(0, nil, "error occurred"); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+}
+
+func TestHelper(t *testing.T) {
+	t.Parallel()
+
+	t.Run("helper", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, line, _ := runtime.Caller(0)
+		err := notFound("key")
+
+		if _, _, got := err.(errs.LocationError).Location(); got != line+1 {
+			t.Fatalf("Location() line: got = %d, want = %d", got, line+1)
+		}
+		if frames := err.(errs.StackError).StackTrace(); frames[0].Line != line+1 {
+			t.Fatalf("frames[0].Line: got = %d, want = %d", frames[0].Line, line+1)
+		}
+	})
+
+	t.Run("nested helpers", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, line, _ := runtime.Caller(0)
+		err := wrapNotFound("key")
+
+		for _, c := range err.(errs.ChainError).Chain() {
+			if _, _, got := c.(errs.LocationError).Location(); got != line+1 {
+				t.Fatalf("Location() line: got = %d, want = %d", got, line+1)
+			}
+		}
+	})
+}
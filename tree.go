@@ -0,0 +1,90 @@
+package errs
+
+import "errors"
+
+// Node defines a node in the causal graph of an error, holding an error along
+// with the nodes of the errors directly resulting to it.
+//
+// For errors created by Wrap and Box, the error of the node is the error of
+// the chain created at that point, rather than the whole wrapping error.
+type Node struct {
+	Err      error
+	Children []*Node
+}
+
+// Tree gives the causal graph of the given error as a tree. Unlike Chain,
+// errors aggregating multiple errors, like the ones created by Append and
+// errors.Join, branch out into a child for each of the aggregated errors.
+//
+// The graph is built following the wrapped and boxed errors of the errors
+// created by this package, Unwrap for other errors, and Chain for other errors
+// implementing ChainError. Errors boxed using BoxWithKey are represented by
+// copies of them, which cannot be used to get the boxed errors.
+//
+// If the given error is nil, then nil is returned.
+func Tree(err error) *Node {
+	if err == nil {
+		return nil
+	}
+	switch e := err.(type) {
+	case *wrapping:
+		return &Node{Err: e.fundamental, Children: []*Node{Tree(e.err)}}
+	case interface{ Unwrap() []error }:
+		node := Node{Err: err}
+		for _, c := range e.Unwrap() {
+			if c != nil {
+				node.Children = append(node.Children, Tree(c))
+			}
+		}
+		return &node
+	case ChainError:
+		return chainTree(e.Chain())
+	default:
+		node := Node{Err: err}
+		if u := errors.Unwrap(err); u != nil {
+			node.Children = []*Node{Tree(u)}
+		}
+		return &node
+	}
+}
+
+// Walk visits each node in the causal graph of the given error, as given by
+// Tree, in depth-first order calling fn for each, until fn returns false.
+func Walk(err error, fn func(n *Node) bool) {
+	walkNode(Tree(err), fn)
+}
+
+func walkNode(n *Node, fn func(n *Node) bool) bool {
+	if n == nil {
+		return true
+	}
+	if !fn(n) {
+		return false
+	}
+	for _, c := range n.Children {
+		if !walkNode(c, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// chainTree gives the tree of a chain of errors not created by this package,
+// which is linear except for the last error of the chain.
+func chainTree(chain []error) *Node {
+	if len(chain) == 0 {
+		return nil
+	}
+	last := chain[len(chain)-1]
+	// The last error is walked further unless it is a chain itself, which
+	// could lead back to the chain.
+	node := &Node{Err: last}
+	_, agg := last.(interface{ Unwrap() []error })
+	if _, ok := last.(ChainError); !ok || agg {
+		node = Tree(last)
+	}
+	for idx := len(chain) - 2; idx >= 0; idx-- {
+		node = &Node{Err: chain[idx], Children: []*Node{node}}
+	}
+	return node
+}
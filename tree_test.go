@@ -0,0 +1,126 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hemantjadon/errs"
+)
+
+func TestTree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		if node := errs.Tree(nil); node != nil {
+			t.Fatalf("expected nil, got %v", node)
+		}
+	})
+
+	t.Run("linear", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := errs.Wrap(errs.Box(baseErr, "error two"), "error one")
+
+		node := errs.Tree(err)
+		msgs := []string{"error one", "error two", "base error"}
+		for idx, msg := range msgs {
+			if node == nil {
+				t.Fatalf("node[%d]: got = nil, want = '%s'", idx, msg)
+			}
+			if node.Err.Error() != msg {
+				t.Fatalf("node[%d].Err.Error(): got = '%s', want = '%s'", idx, node.Err.Error(), msg)
+			}
+			if idx == len(msgs)-1 {
+				if len(node.Children) != 0 {
+					t.Fatalf("len(node[%d].Children): got = %d, want = %d", idx, len(node.Children), 0)
+				}
+				break
+			}
+			if len(node.Children) != 1 {
+				t.Fatalf("len(node[%d].Children): got = %d, want = %d", idx, len(node.Children), 1)
+			}
+			node = node.Children[0]
+		}
+	})
+
+	t.Run("branches", func(t *testing.T) {
+		t.Parallel()
+
+		err1 := errs.Wrap(errors.New("base error"), "error one")
+		err2 := fmt.Errorf("error two: %w", errs.New("inner error"))
+		err := errs.Wrap(errors.Join(err1, err2), "error occurred")
+
+		node := errs.Tree(err)
+		if node.Err.Error() != "error occurred" {
+			t.Fatalf("node.Err.Error(): got = '%s', want = '%s'", node.Err.Error(), "error occurred")
+		}
+		if len(node.Children) != 1 {
+			t.Fatalf("len(node.Children): got = %d, want = %d", len(node.Children), 1)
+		}
+		join := node.Children[0]
+		if len(join.Children) != 2 {
+			t.Fatalf("len(join.Children): got = %d, want = %d", len(join.Children), 2)
+		}
+		if join.Children[0].Err.Error() != "error one" {
+			t.Fatalf("join.Children[0].Err.Error(): got = '%s', want = '%s'", join.Children[0].Err.Error(), "error one")
+		}
+		if len(join.Children[0].Children) != 1 {
+			t.Fatalf("len(join.Children[0].Children): got = %d, want = %d", len(join.Children[0].Children), 1)
+		}
+		if join.Children[1].Err != err2 {
+			t.Fatalf("join.Children[1].Err: got = '%v', want = '%v'", join.Children[1].Err, err2)
+		}
+		if len(join.Children[1].Children) != 1 {
+			t.Fatalf("len(join.Children[1].Children): got = %d, want = %d", len(join.Children[1].Children), 1)
+		}
+	})
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	err1 := errs.Wrap(errors.New("base error"), "error one")
+	err2 := errs.New("error two")
+	err := errs.Box(errs.Append(err1, err2), "error occurred")
+
+	t.Run("depth-first", func(t *testing.T) {
+		t.Parallel()
+
+		var msgs []string
+		errs.Walk(err, func(n *errs.Node) bool {
+			if _, ok := n.Err.(errs.MultiError); ok {
+				msgs = append(msgs, "multi")
+			} else {
+				msgs = append(msgs, n.Err.Error())
+			}
+			return true
+		})
+
+		want := []string{"error occurred", "multi", "error one", "base error", "error two"}
+		if len(msgs) != len(want) {
+			t.Fatalf("visited: got = '%v', want = '%v'", msgs, want)
+		}
+		for idx := range want {
+			if msgs[idx] != want[idx] {
+				t.Fatalf("visited: got = '%v', want = '%v'", msgs, want)
+			}
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		t.Parallel()
+
+		var count int
+		errs.Walk(err, func(n *errs.Node) bool {
+			count++
+			return count < 3
+		})
+		if count != 3 {
+			t.Fatalf("visited: got = %d, want = %d", count, 3)
+		}
+	})
+}